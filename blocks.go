@@ -2,17 +2,25 @@
 package timeslots
 
 import (
-	"fmt"
+	"sort"
 	"time"
 )
 
 // This refers to already scheduled events. The term ‘Block’ will be standardized here.”
 type Block struct {
-	start time.Time
-	end   time.Time
+	start  time.Time
+	end    time.Time
+	buffer *Buffer
+	units  *int
 	Period
 }
 
+// This is the time kept free before and after a Block. (e.g. cleanup time after a reservation, charging time before a rental)
+type Buffer struct {
+	Before time.Duration
+	After  time.Duration
+}
+
 // Creates a new Block without validation. Use this when the order of start and end is guaranteed.
 func NewBlockWithoutValidating(start, end time.Time) *Block {
 	return &Block{
@@ -23,25 +31,45 @@ func NewBlockWithoutValidating(start, end time.Time) *Block {
 
 // Creates a new Block with validation. It verifies the order of start and end.
 func NewBlock(start, end time.Time) (*Block, error) {
-	if start.After(end) {
-		return nil, fmt.Errorf("invalid time arguments")
+	if err := validate(start, end); err != nil {
+		return nil, err
 	}
 	return NewBlockWithoutValidating(start, end), nil
 }
 
 // Generates a slice of Blocks. Specify your struct with time-related fields as input, and define a mapping function between input and Block in the mapper.
+// It stops at the first failure and returns *IndexError with the index of the input.
 func NewBlocks[T any](inputs []T, mapper func(T) (*Block, error)) ([]*Block, error) {
 	blocks := make([]*Block, len(inputs))
 	for i, in := range inputs {
 		block, err := mapper(in)
 		if err != nil {
-			return nil, err
+			return nil, &IndexError{Index: i, Err: err}
 		}
 		blocks[i] = block
 	}
 	return blocks, nil
 }
 
+// Generates a slice of Blocks like NewBlocks, but skips the failed inputs instead of stopping.
+// The Blocks of the other inputs are returned together with *BlocksError, which holds the errors of all the failed inputs.
+func NewBlocksLenient[T any](inputs []T, mapper func(T) (*Block, error)) ([]*Block, error) {
+	blocks := make([]*Block, 0, len(inputs))
+	var errs []*IndexError
+	for i, in := range inputs {
+		block, err := mapper(in)
+		if err != nil {
+			errs = append(errs, &IndexError{Index: i, Err: err})
+			continue
+		}
+		blocks = append(blocks, block)
+	}
+	if len(errs) > 0 {
+		return blocks, &BlocksError{Errors: errs}
+	}
+	return blocks, nil
+}
+
 // Start time of the period.
 func (b *Block) Start() time.Time {
 	return b.start
@@ -52,11 +80,98 @@ func (b *Block) End() time.Time {
 	return b.end
 }
 
+// Copy the Block with the start time and end time converted into the location. The buffer and units are kept.
+func (b *Block) In(loc *time.Location) *Block {
+	return &Block{
+		start:  b.start.In(loc),
+		end:    b.end.In(loc),
+		buffer: b.buffer,
+		units:  b.units,
+	}
+}
+
+// Convert the blocks from different locations (e.g. UTC rows from a database) into the location. The given slice is left untouched.
+func BlocksIn(blocks []*Block, loc *time.Location) []*Block {
+	converted := make([]*Block, len(blocks))
+	for i, block := range blocks {
+		converted[i] = block.In(loc)
+	}
+	return converted
+}
+
 // Represents the start time and end time as strings.
 func (b *Block) String() string {
 	return format(b)
 }
 
+// Copy the Block with the buffer time before and after it. This takes precedence over the buffer given as an option.
+func (b *Block) WithBuffer(before, after time.Duration) *Block {
+	return &Block{
+		start: b.start,
+		end:   b.end,
+		buffer: &Buffer{
+			Before: before,
+			After:  after,
+		},
+		units: b.units,
+	}
+}
+
+// Copy the Block consuming the units of a resource with capacity. (e.g. 4 seats of a restaurant section)
+// Zero units consume nothing (e.g. a tentative hold), and negative units are treated as zero.
+func (b *Block) WithUnits(units int) *Block {
+	units = max(units, 0)
+	return &Block{
+		start:  b.start,
+		end:    b.end,
+		buffer: b.buffer,
+		units:  &units,
+	}
+}
+
+// The units of a resource the Block consumes. It is 1 unless WithUnits is used.
+func (b *Block) Units() int {
+	if b.units == nil {
+		return 1
+	}
+	return *b.units
+}
+
+// Expand the Block by its own buffer, or by the given buffer if the Block has none.
+func (b *Block) padded(buffer *Buffer) *Block {
+	if b.buffer != nil {
+		buffer = b.buffer
+	}
+	if buffer == nil {
+		return b
+	}
+	return &Block{
+		start: b.start.Add(-buffer.Before),
+		end:   b.end.Add(buffer.After),
+		units: b.units,
+	}
+}
+
+// Expand the blocks by the buffers. The given slice is returned as it is when there is nothing to expand, otherwise a new slice sorted by the start time is returned.
+func pad(blocks []*Block, buffer *Buffer) []*Block {
+	needed := buffer != nil
+	for i := 0; !needed && i < len(blocks); i++ {
+		needed = blocks[i].buffer != nil
+	}
+	if !needed {
+		return blocks
+	}
+
+	padded := make([]*Block, len(blocks))
+	for i, block := range blocks {
+		padded[i] = block.padded(buffer)
+	}
+	sort.Slice(padded, func(i, j int) bool {
+		return padded[i].Start().Before(padded[j].Start())
+	})
+	return padded
+}
+
 func beforeEq(s, t time.Time) bool {
 	return s.Before(t) || s.Equal(t)
 }
@@ -80,3 +195,14 @@ func (b *Block) OverlapAtEnd(other Period) bool {
 func (b *Block) OverlapAtStart(other Period) bool {
 	return beforeEq(b.start, other.Start()) && beforeEq(b.end, other.End()) && other.Start().Before(b.end)
 }
+
+// Whether the Block shares any time with the given Period. Touching at the start or end time is not an overlap, and an empty Block never overlaps.
+func (b *Block) Overlaps(other Period) bool {
+	if !b.start.Before(b.end) {
+		return false
+	}
+	if b.OverlapAtStart(other) || b.OverlapAtEnd(other) {
+		return true
+	}
+	return (b.Contains(other) || b.IsContainedIn(other)) && b.start.Before(other.End()) && other.Start().Before(b.end)
+}
@@ -582,3 +582,37 @@ func TestBlockString(t *testing.T) {
 		t.Errorf("Slot.String() = %s; want %s", got, want)
 	}
 }
+
+func TestOverlaps(t *testing.T) {
+	h := NewTestingHelper(now)
+	block := h.Block(0, 8)
+
+	tests := []struct {
+		name  string
+		other timeslots.Period
+		want  bool
+	}{
+		{name: "Before", other: h.Slot(-2, -1), want: false},
+		{name: "Touching the start", other: h.Slot(-1, 0), want: false},
+		{name: "Overlap at start", other: h.Slot(-1, 1), want: true},
+		{name: "Contains", other: h.Slot(1, 2), want: true},
+		{name: "Is contained in", other: h.Slot(-1, 9), want: true},
+		{name: "Same period", other: h.Slot(0, 8), want: true},
+		{name: "Overlap at end", other: h.Slot(7, 9), want: true},
+		{name: "Touching the end", other: h.Slot(8, 9), want: false},
+		{name: "Instant inside", other: h.Slot(4, 4), want: true},
+		{name: "Instant at the end", other: h.Slot(8, 8), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := block.Overlaps(tt.other); got != tt.want {
+				t.Errorf("Overlaps() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if h.Block(4, 4).Overlaps(h.Slot(0, 8)) {
+		t.Errorf("empty Block overlaps")
+	}
+}
@@ -0,0 +1,131 @@
+package timeslots
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+)
+
+// This refers to available time of a resource with capacity. It reports the remaining units during the Slot.
+type CapacitySlot struct {
+	*Slot
+	Remaining int
+}
+
+// The JSON representation of a CapacitySlot.
+type capacitySlotJSON struct {
+	periodJSON
+	Remaining int `json:"remaining"`
+}
+
+// Implements json.Marshaler in the form of {"start": ..., "end": ..., "remaining": ...}.
+func (c *CapacitySlot) MarshalJSON() ([]byte, error) {
+	return json.Marshal(capacitySlotJSON{
+		periodJSON: periodJSON{Start: c.start, End: c.end},
+		Remaining:  c.Remaining,
+	})
+}
+
+// Implements json.Unmarshaler. It is validated in the same way as NewSlot.
+func (c *CapacitySlot) UnmarshalJSON(data []byte) error {
+	var v capacitySlotJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	slot, err := NewSlot(v.Start, v.End)
+	if err != nil {
+		return err
+	}
+	c.Slot = slot
+	c.Remaining = v.Remaining
+	return nil
+}
+
+// Implements encoding.TextMarshaler to reject the text form, which cannot hold the remaining units. Use JSON instead.
+func (c *CapacitySlot) MarshalText() ([]byte, error) {
+	return nil, errors.New("a CapacitySlot cannot be represented as text")
+}
+
+// Implements encoding.TextUnmarshaler to reject the text form, which cannot hold the remaining units. Use JSON instead.
+func (c *CapacitySlot) UnmarshalText(text []byte) error {
+	return errors.New("a CapacitySlot cannot be represented as text")
+}
+
+// Calculate the time slots in which at least the needed units of a resource with the capacity remain. (e.g. 12 seats of a restaurant section)
+// Each Block consumes its Units, and overlapping Blocks make the resource unavailable only when they reach the capacity.
+// Consecutive time slots in which the needed units remain are merged into one, which reports the fewest remaining units during it. A need less than 1 is treated as 1.
+// The options are applied in the same way as Find, and the alignment and duration options are applied to each merged time slot.
+func FindCapacity(blocks []*Block, span *Span, capacity, need int, opts ...Option[*CapacitySlot]) []*CapacitySlot {
+	options := newOptions(opts)
+	span = options.clip(span)
+
+	if span == nil || !span.Remain() {
+		return []*CapacitySlot{}
+	}
+	if need < 1 {
+		need = 1
+	}
+
+	type change struct {
+		at    time.Time
+		units int
+	}
+	changes := []change{{at: span.start}, {at: span.end}}
+	for _, block := range blocks {
+		block = block.padded(options.Buffer)
+		if !block.Overlaps(span) {
+			continue
+		}
+		start, end := block.start, block.end
+		if start.Before(span.start) {
+			start = span.start
+		}
+		if end.After(span.end) {
+			end = span.end
+		}
+		changes = append(changes, change{at: start, units: block.Units()}, change{at: end, units: -block.Units()})
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].at.Before(changes[j].at)
+	})
+
+	slots := []*CapacitySlot{}
+	used := 0
+	for i := 0; i < len(changes)-1; i++ {
+		used += changes[i].units
+		start, end := changes[i].at, changes[i+1].at
+		if !start.Before(end) {
+			continue
+		}
+
+		remaining := max(capacity-used, 0)
+		if remaining < need {
+			continue
+		}
+		if n := len(slots) - 1; n >= 0 && slots[n].end.Equal(start) {
+			slots[n].end = end
+			slots[n].Remaining = min(slots[n].Remaining, remaining)
+			continue
+		}
+		slots = append(slots, &CapacitySlot{
+			Slot:      newSlot(start, end),
+			Remaining: remaining,
+		})
+	}
+
+	loc := options.location(span)
+	found := []*CapacitySlot{}
+	for _, slot := range slots {
+		slot.localize(loc)
+		options.constrain(slot.Slot, func(s *Slot) bool {
+			c := &CapacitySlot{Slot: s, Remaining: slot.Remaining}
+			if options.IsSetFilter() && options.FilterFunc(c) {
+				return true
+			}
+			found = append(found, c)
+			return true
+		})
+	}
+	return found
+}
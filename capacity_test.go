@@ -0,0 +1,205 @@
+package timeslots_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+	"timeslots"
+)
+
+func TestFindCapacity(t *testing.T) {
+	h := NewTestingHelper(now)
+
+	type want struct {
+		slot      *timeslots.Slot
+		remaining int
+	}
+
+	blocks := []*timeslots.Block{
+		h.Block(1, 3).WithUnits(4),
+		h.Block(2, 4).WithUnits(8),
+		h.Block(5, 6),
+		h.Block(9, 10).WithUnits(12),
+	}
+
+	tests := []struct {
+		name     string
+		blocks   []*timeslots.Block
+		search   *timeslots.Span
+		capacity int
+		need     int
+		opts     []timeslots.Option[*timeslots.CapacitySlot]
+		want     []want
+	}{
+		{
+			name:     "Nil span",
+			blocks:   blocks,
+			search:   nil,
+			capacity: 12,
+			want:     []want{},
+		},
+		{
+			name:     "Remaining capacity",
+			blocks:   blocks,
+			search:   h.Span(0, 8),
+			capacity: 12,
+			want: []want{
+				{slot: h.Slot(0, 2), remaining: 8},
+				{slot: h.Slot(3, 8), remaining: 4},
+			},
+		},
+		{
+			name:     "At least K units",
+			blocks:   blocks,
+			search:   h.Span(0, 8),
+			capacity: 12,
+			need:     5,
+			want: []want{
+				{slot: h.Slot(0, 2), remaining: 8},
+				{slot: h.Slot(4, 8), remaining: 11},
+			},
+		},
+		{
+			name:     "Full outside the span",
+			blocks:   blocks,
+			search:   h.Span(8, 11),
+			capacity: 12,
+			want: []want{
+				{slot: h.Slot(8, 9), remaining: 12},
+				{slot: h.Slot(10, 11), remaining: 12},
+			},
+		},
+		{
+			name:     "Min duration across the remaining units",
+			blocks:   []*timeslots.Block{h.Block(1, 2).WithUnits(4)},
+			search:   h.Span(0, 2),
+			capacity: 12,
+			need:     5,
+			opts: []timeslots.Option[*timeslots.CapacitySlot]{
+				timeslots.WithMinDuration[*timeslots.CapacitySlot](2 * time.Hour),
+			},
+			want: []want{
+				{slot: h.Slot(0, 2), remaining: 8},
+			},
+		},
+		{
+			name:     "With buffer and filter",
+			blocks:   []*timeslots.Block{h.Block(2, 3).WithUnits(8)},
+			search:   h.Span(0, 8),
+			capacity: 8,
+			opts: []timeslots.Option[*timeslots.CapacitySlot]{
+				timeslots.WithBuffer[*timeslots.CapacitySlot](0, time.Hour),
+				timeslots.WithFilter(func(s *timeslots.CapacitySlot) bool {
+					return s.End().Sub(s.Start()) < 3*time.Hour
+				}),
+			},
+			want: []want{
+				{slot: h.Slot(4, 8), remaining: 8},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := timeslots.FindCapacity(tt.blocks, tt.search, tt.capacity, tt.need, tt.opts...)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d slots, want %d slots", len(got), len(tt.want))
+			}
+			for i, w := range tt.want {
+				if !got[i].Slot.Equal(w.slot) || got[i].Remaining != w.remaining {
+					t.Errorf("slot[%d] = %v (%d), want %v (%d)", i, got[i].Slot, got[i].Remaining, w.slot, w.remaining)
+				}
+			}
+		})
+	}
+}
+
+func TestBlockUnits(t *testing.T) {
+	h := NewTestingHelper(now)
+	block := h.Block(0, 1)
+	if block.Units() != 1 {
+		t.Errorf("Units() = %d, want 1", block.Units())
+	}
+	if got := block.WithUnits(3).WithBuffer(time.Hour, 0).Units(); got != 3 {
+		t.Errorf("Units() = %d, want 3", got)
+	}
+	if got := block.WithUnits(0).Units(); got != 0 {
+		t.Errorf("WithUnits(0).Units() = %d, want 0", got)
+	}
+	if got := block.WithUnits(-3).Units(); got != 0 {
+		t.Errorf("WithUnits(-3).Units() = %d, want 0", got)
+	}
+
+	slots := timeslots.FindCapacity([]*timeslots.Block{block.WithUnits(0)}, h.Span(0, 2), 1, 1)
+	if len(slots) != 1 || !slots[0].Slot.Equal(h.Slot(0, 2)) || slots[0].Remaining != 1 {
+		t.Errorf("a Block with zero units consumes the capacity: %v", slots)
+	}
+}
+
+func TestCapacitySlotMarshalJSON(t *testing.T) {
+	h := NewTestingHelper(time.Date(2024, 9, 26, 0, 0, 0, 0, time.UTC))
+	slots := timeslots.FindCapacity([]*timeslots.Block{h.Block(0, 1).WithUnits(2)}, h.Span(0, 1), 3, 1)
+
+	data, err := json.Marshal(slots)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"start":"2024-09-26T00:00:00Z","end":"2024-09-26T01:00:00Z","remaining":1}]`
+	if string(data) != want {
+		t.Errorf("json.Marshal() = %v, want %v", string(data), want)
+	}
+}
+
+func TestFindCapacityOptions(t *testing.T) {
+	h := NewTestingHelper(now)
+	blocks := []*timeslots.Block{h.Block(5, 6).WithUnits(2)}
+	policy := timeslots.NewPolicy(nil, timeslots.WithClock(func() time.Time { return now.Add(4 * time.Hour) }))
+
+	got := timeslots.FindCapacity(blocks, h.Span(0, 8), 2, 1,
+		timeslots.WithPolicy[*timeslots.CapacitySlot](policy),
+		timeslots.WithMaxDuration[*timeslots.CapacitySlot](time.Hour),
+	)
+	want := []*timeslots.Slot{h.Slot(4, 5), h.Slot(6, 7), h.Slot(7, 8)}
+	if len(got) != len(want) {
+		t.Fatalf("got %d slots, want %d slots", len(got), len(want))
+	}
+	for i, w := range want {
+		if !got[i].Slot.Equal(w) || got[i].Remaining != 2 {
+			t.Errorf("slot[%d] = %v (%d), want %v (2)", i, got[i].Slot, got[i].Remaining, w)
+		}
+	}
+}
+
+func TestCapacitySlotUnmarshalJSON(t *testing.T) {
+	h := NewTestingHelper(time.Date(2024, 9, 26, 0, 0, 0, 0, time.UTC))
+	slots := timeslots.FindCapacity([]*timeslots.Block{h.Block(1, 2).WithUnits(2)}, h.Span(0, 3), 3, 1)
+
+	data, err := json.Marshal(slots)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []*timeslots.CapacitySlot
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(slots) {
+		t.Fatalf("got %d slots, want %d slots", len(got), len(slots))
+	}
+	for i, slot := range slots {
+		if !got[i].Slot.Equal(slot.Slot) || got[i].Remaining != slot.Remaining {
+			t.Errorf("slot[%d] = %v (%d), want %v (%d)", i, got[i].Slot, got[i].Remaining, slot.Slot, slot.Remaining)
+		}
+	}
+
+	var inverted timeslots.CapacitySlot
+	if err := json.Unmarshal([]byte(`{"start":"2024-09-26T01:00:00Z","end":"2024-09-26T00:00:00Z","remaining":1}`), &inverted); !errors.Is(err, timeslots.ErrInvertedPeriod) {
+		t.Errorf("json.Unmarshal() error = %v, want %v", err, timeslots.ErrInvertedPeriod)
+	}
+	if _, err := slots[0].MarshalText(); err == nil {
+		t.Errorf("MarshalText() error = nil, want an error")
+	}
+	if err := inverted.UnmarshalText([]byte("2024-09-26T00:00:00Z/2024-09-26T01:00:00Z")); err == nil {
+		t.Errorf("UnmarshalText() error = nil, want an error")
+	}
+}
@@ -0,0 +1,20 @@
+package timeslots
+
+import (
+	"slices"
+)
+
+// Calculate the time slots (Slot) in which every entity is free. Provide the scheduled blocks (Block) of each entity keyed by any identifier, and the target period (Span).
+// The blocks of the entities are never mixed up in the given map, so it is safe to pass schedules held by different entities.
+func FindCommon[K comparable](calendars map[K][]*Block, span *Span, opts ...Option[*Slot]) []*Slot {
+	size := 0
+	for _, blocks := range calendars {
+		size += len(blocks)
+	}
+
+	merged := make([]*Block, 0, size)
+	for _, blocks := range calendars {
+		merged = append(merged, blocks...)
+	}
+	return Find(merged, span, append(slices.Clip(opts), WithInPlaceSort[*Slot]())...)
+}
@@ -0,0 +1,79 @@
+package timeslots_test
+
+import (
+	"testing"
+	"time"
+	"timeslots"
+	"timeslots/internal/slice"
+)
+
+func TestFindCommon(t *testing.T) {
+	h := NewTestingHelper(now)
+
+	tests := []struct {
+		name      string
+		calendars map[string][]*timeslots.Block
+		search    *timeslots.Span
+		filter    timeslots.FilterFunc[*timeslots.Slot]
+		want      []*timeslots.Slot
+	}{
+		{
+			name:      "No calendars",
+			calendars: map[string][]*timeslots.Block{},
+			search:    h.Span(0, 8),
+			want:      []*timeslots.Slot{h.Slot(0, 8)},
+		},
+		{
+			name: "Single calendar",
+			calendars: map[string][]*timeslots.Block{
+				"alice": {h.Block(1, 2)},
+			},
+			search: h.Span(0, 8),
+			want:   []*timeslots.Slot{h.Slot(0, 1), h.Slot(2, 8)},
+		},
+		{
+			name: "Multiple calendars",
+			calendars: map[string][]*timeslots.Block{
+				"alice":  {h.Block(1, 2), h.Block(6, 7)},
+				"bob":    {h.Block(3, 4)},
+				"room-3": {h.Block(-1, 0), h.Block(3, 5)},
+			},
+			search: h.Span(0, 8),
+			want:   []*timeslots.Slot{h.Slot(0, 1), h.Slot(2, 3), h.Slot(5, 6), h.Slot(7, 8)},
+		},
+		{
+			name: "Multiple calendars with filter",
+			calendars: map[string][]*timeslots.Block{
+				"alice": {h.Block(1, 2)},
+				"bob":   {h.Block(3, 4)},
+			},
+			search: h.Span(0, 8),
+			filter: func(s *timeslots.Slot) bool {
+				return s.End().Sub(s.Start()) < (2 * time.Hour)
+			},
+			want: []*timeslots.Slot{h.Slot(4, 8)},
+		},
+		{
+			name: "Nobody is free",
+			calendars: map[string][]*timeslots.Block{
+				"alice": {h.Block(0, 4)},
+				"bob":   {h.Block(4, 8)},
+			},
+			search: h.Span(0, 8),
+			want:   []*timeslots.Slot{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []timeslots.Option[*timeslots.Slot]{}
+			if tt.filter != nil {
+				opts = append(opts, timeslots.WithFilter(tt.filter))
+			}
+			got := timeslots.FindCommon(tt.calendars, tt.search, opts...)
+			if !slice.Equal(got, tt.want) {
+				t.Errorf("got: %v, want: %v", slice.String(got), slice.String(tt.want))
+			}
+		})
+	}
+}
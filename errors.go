@@ -0,0 +1,103 @@
+package timeslots
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// The start time is after the end time.
+	ErrInvertedPeriod = errors.New("the start is after the end")
+	// The start time is equal to the end time.
+	ErrZeroLengthPeriod = errors.New("the start is equal to the end")
+	// The start time and the end time are in different locations.
+	ErrLocationMismatch = errors.New("the start and the end are in different locations")
+	// The period is not contained in the Span.
+	ErrOutOfRange = errors.New("the period is out of range")
+)
+
+// This is returned when the start time and end time of a period are invalid. Use errors.Is with the sentinel errors to find the reason.
+type PeriodError struct {
+	Start time.Time
+	End   time.Time
+	Err   error
+}
+
+func (e *PeriodError) Error() string {
+	return fmt.Sprintf("invalid time arguments: %s, %s: %v", e.Start.Format(TimeFormat), e.End.Format(TimeFormat), e.Err)
+}
+
+func (e *PeriodError) Unwrap() error {
+	return e.Err
+}
+
+// This is the error of the input at the index given to NewBlocks.
+type IndexError struct {
+	Index int
+	Err   error
+}
+
+func (e *IndexError) Error() string {
+	return fmt.Sprintf("index %d: %v", e.Index, e.Err)
+}
+
+func (e *IndexError) Unwrap() error {
+	return e.Err
+}
+
+// This is returned by NewBlocksLenient and holds the errors of all the failed inputs in the order of the index.
+type BlocksError struct {
+	Errors []*IndexError
+}
+
+func (e *BlocksError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("%d invalid input(s): %s", len(e.Errors), strings.Join(messages, "; "))
+}
+
+func (e *BlocksError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// This is the check used by the constructors. A zero-length period is allowed there.
+func validate(start, end time.Time) error {
+	if start.After(end) {
+		return &PeriodError{Start: start, End: end, Err: ErrInvertedPeriod}
+	}
+	return nil
+}
+
+// Validate the period strictly. In addition to the order of start and end, it rejects a zero-length period and a period whose start and end are in different locations.
+func Validate(p Period) error {
+	start, end := p.Start(), p.End()
+	if err := validate(start, end); err != nil {
+		return err
+	}
+	if start.Equal(end) {
+		return &PeriodError{Start: start, End: end, Err: ErrZeroLengthPeriod}
+	}
+	if start.Location().String() != end.Location().String() {
+		return &PeriodError{Start: start, End: end, Err: ErrLocationMismatch}
+	}
+	return nil
+}
+
+// Validate the period strictly and verify that it is contained in the Span.
+func ValidateWithin(p Period, span *Span) error {
+	if err := Validate(p); err != nil {
+		return err
+	}
+	if span == nil || p.Start().Before(span.start) || p.End().After(span.end) {
+		return &PeriodError{Start: p.Start(), End: p.End(), Err: ErrOutOfRange}
+	}
+	return nil
+}
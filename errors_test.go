@@ -0,0 +1,130 @@
+package timeslots_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+	"timeslots"
+)
+
+func TestPeriodError(t *testing.T) {
+	_, spanErr := timeslots.NewSpan(now.Add(time.Hour), now)
+	_, blockErr := timeslots.NewBlock(now.Add(time.Hour), now)
+	_, slotErr := timeslots.NewSlot(now.Add(time.Hour), now)
+
+	for _, err := range []error{spanErr, blockErr, slotErr} {
+		var periodErr *timeslots.PeriodError
+		if !errors.As(err, &periodErr) {
+			t.Fatalf("errors.As(%v) = false, want true", err)
+		}
+		if !errors.Is(err, timeslots.ErrInvertedPeriod) {
+			t.Errorf("errors.Is(%v, ErrInvertedPeriod) = false, want true", err)
+		}
+		if !periodErr.Start.Equal(now.Add(time.Hour)) || !periodErr.End.Equal(now) {
+			t.Errorf("PeriodError = %v, %v", periodErr.Start, periodErr.End)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	h := NewTestingHelper(now)
+	tokyo := time.FixedZone("Asia/Tokyo", 9*60*60)
+
+	tests := []struct {
+		name    string
+		period  timeslots.Period
+		span    *timeslots.Span
+		wantErr error
+	}{
+		{
+			name:   "Valid period",
+			period: h.Block(1, 2),
+			span:   h.Span(0, 8),
+		},
+		{
+			name:    "Inverted period",
+			period:  timeslots.NewBlockWithoutValidating(now.Add(time.Hour), now),
+			span:    h.Span(0, 8),
+			wantErr: timeslots.ErrInvertedPeriod,
+		},
+		{
+			name:    "Zero-length period",
+			period:  h.Block(1, 1),
+			span:    h.Span(0, 8),
+			wantErr: timeslots.ErrZeroLengthPeriod,
+		},
+		{
+			name:    "Different locations",
+			period:  timeslots.NewBlockWithoutValidating(now.UTC(), now.Add(time.Hour).In(tokyo)),
+			span:    h.Span(-1, 8),
+			wantErr: timeslots.ErrLocationMismatch,
+		},
+		{
+			name:    "Out of range",
+			period:  h.Block(7, 9),
+			span:    h.Span(0, 8),
+			wantErr: timeslots.ErrOutOfRange,
+		},
+		{
+			name:    "Nil span",
+			period:  h.Block(1, 2),
+			span:    nil,
+			wantErr: timeslots.ErrOutOfRange,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := timeslots.ValidateWithin(tt.period, tt.span)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Errorf("ValidateWithin() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewBlocksLenient(t *testing.T) {
+	type Input struct {
+		start time.Time
+		end   time.Time
+	}
+	inputs := []Input{
+		{start: now, end: now.Add(1 * time.Hour)},
+		{start: now.Add(3 * time.Hour), end: now.Add(2 * time.Hour)},
+		{start: now.Add(4 * time.Hour), end: now.Add(5 * time.Hour)},
+		{},
+	}
+	mapper := func(in Input) (*timeslots.Block, error) {
+		if in.start.IsZero() {
+			return nil, fmt.Errorf("missing start")
+		}
+		return timeslots.NewBlock(in.start, in.end)
+	}
+
+	blocks, err := timeslots.NewBlocksLenient(inputs, mapper)
+	if len(blocks) != 2 {
+		t.Fatalf("got %d blocks, want 2", len(blocks))
+	}
+
+	var blocksErr *timeslots.BlocksError
+	if !errors.As(err, &blocksErr) {
+		t.Fatalf("errors.As(%v) = false, want true", err)
+	}
+	if len(blocksErr.Errors) != 2 || blocksErr.Errors[0].Index != 1 || blocksErr.Errors[1].Index != 3 {
+		t.Errorf("BlocksError = %v", blocksErr)
+	}
+	if !errors.Is(err, timeslots.ErrInvertedPeriod) {
+		t.Errorf("errors.Is(%v, ErrInvertedPeriod) = false, want true", err)
+	}
+
+	_, err = timeslots.NewBlocks(inputs, mapper)
+	var indexErr *timeslots.IndexError
+	if !errors.As(err, &indexErr) || indexErr.Index != 1 {
+		t.Errorf("NewBlocks() error = %v, want the error at index 1", err)
+	}
+
+	if _, err := timeslots.NewBlocksLenient(inputs[:1], mapper); err != nil {
+		t.Errorf("NewBlocksLenient() error = %v, want nil", err)
+	}
+}
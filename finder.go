@@ -1,21 +1,50 @@
 package timeslots
 
 import (
+	"errors"
+	"iter"
+	"slices"
 	"sort"
+	"time"
+
+	"timeslots/internal/slice"
 )
 
+// This is returned when the blocks given as a sequence are not sorted by the start time.
+var ErrUnsortedBlocks = errors.New("blocks are not sorted by the start time")
+
 // Map your struct to a Block.
 type MapInFunc[In Period] func(In) *Block
 
 // Map the Slot to your struct.
 type MapOutFunc[Out any] func(*Slot) Out
 
-// (Optional)Filter your struct in your condition.
+// (Optional)Filter your struct in your condition. Note that the result is dropped when it returns true. Use WithKeep or WithExclude to make it explicit.
 type FilterFunc[Out any] func(Out) bool
 
+// How the input is sorted before searching.
+type SortMode int
+
+const (
+	// Sort a copy of the input. The given slice is left untouched. (default)
+	SortCopy SortMode = iota
+	// Sort the given slice in place to save the allocation.
+	SortInPlace
+	// Trust that the input is already sorted by the start time. It is validated, and a copy is sorted when it is not.
+	Presorted
+)
+
 // Options
 type Options[Out any] struct {
-	FilterFunc FilterFunc[Out]
+	FilterFunc  FilterFunc[Out]
+	Buffer      *Buffer
+	Sort        SortMode
+	Location    *time.Location
+	MinDuration time.Duration
+	MaxDuration time.Duration
+	Grid        time.Duration
+	Anchor      time.Time
+	Policy      *Policy
 }
 
 // Whether the FilterFunc is set to Options
@@ -23,116 +52,287 @@ func (o *Options[Out]) IsSetFilter() bool {
 	return o.FilterFunc != nil
 }
 
+// Whether the Buffer is set to Options
+func (o *Options[Out]) IsSetBuffer() bool {
+	return o.Buffer != nil
+}
+
+// Whether the Location is set to Options
+func (o *Options[Out]) IsSetLocation() bool {
+	return o.Location != nil
+}
+
+// The location of the results. It is the location of the Span unless it is set.
+func (o *Options[Out]) location(span *Span) *time.Location {
+	if o.IsSetLocation() || span == nil {
+		return o.Location
+	}
+	return span.Location()
+}
+
+// Whether the MinDuration is set to Options
+func (o *Options[Out]) IsSetMinDuration() bool {
+	return o.MinDuration > 0
+}
+
+// Whether the MaxDuration is set to Options
+func (o *Options[Out]) IsSetMaxDuration() bool {
+	return o.MaxDuration > 0
+}
+
+// Whether the Policy is set to Options
+func (o *Options[Out]) IsSetPolicy() bool {
+	return o.Policy != nil
+}
+
+// The Span clipped by the Policy, if it is set.
+func (o *Options[Out]) clip(span *Span) *Span {
+	if !o.IsSetPolicy() {
+		return span
+	}
+	return o.Policy.Clip(span)
+}
+
+// Whether the Grid is set to Options
+func (o *Options[Out]) IsSetGrid() bool {
+	return o.Grid > 0
+}
+
+// Add the condition to drop the result, in addition to the FilterFunc already set.
+func (o *Options[Out]) exclude(f FilterFunc[Out]) {
+	if f == nil {
+		return
+	}
+	if !o.IsSetFilter() {
+		o.FilterFunc = f
+		return
+	}
+	previous := o.FilterFunc
+	o.FilterFunc = func(out Out) bool {
+		return previous(out) || f(out)
+	}
+}
+
+// Apply Grid, MaxDuration and MinDuration to the Slot, and yield the Slots which remain.
+func (o *Options[Out]) constrain(slot *Slot, yield func(*Slot) bool) bool {
+	if o.IsSetGrid() {
+		anchor := o.Anchor
+		if anchor.IsZero() {
+			anchor = midnight(slot.start)
+		}
+		start, end := alignUp(slot.start, o.Grid, anchor), alignDown(slot.end, o.Grid, anchor)
+		if !start.Before(end) {
+			return true
+		}
+		slot = newSlot(start, end)
+	}
+	next := func(s *Slot) bool {
+		if o.IsSetMinDuration() && s.end.Sub(s.start) < o.MinDuration {
+			return true
+		}
+		return yield(s)
+	}
+	if o.IsSetMaxDuration() {
+		for slot.end.Sub(slot.start) > o.MaxDuration {
+			head := newSlot(slot.start, slot.start.Add(o.MaxDuration))
+			slot = newSlot(head.end, slot.end)
+			if !next(head) {
+				return false
+			}
+		}
+	}
+	return next(slot)
+}
+
 // Option Func
 type Option[Out any] func(*Options[Out])
 
-// Run with filter option
+// Run with filter option. The results for which the filter returns true are dropped. It is combined with the other filters, like WithExclude.
 func WithFilter[Out any](filter FilterFunc[Out]) Option[Out] {
 	return func(opts *Options[Out]) {
-		opts.FilterFunc = filter
+		opts.exclude(filter)
 	}
 }
 
-// Calculate available time slots (Slot). Provide the scheduled block (Block) and the target period (Span).
-// Use this when passing and returning your struct.
-func FindWithMapper[In Period, Out any](inputs []In, span *Span, mapin MapInFunc[In], mapout MapOutFunc[Out], opts ...Option[Out]) []Out {
-	options := Options[Out]{
-		FilterFunc: nil,
-	}
-	for _, opt := range opts {
-		opt(&options)
+// Run with dropping the results for which the function returns true. It is combined with the other filters.
+func WithExclude[Out any](exclude func(Out) bool) Option[Out] {
+	return func(opts *Options[Out]) {
+		opts.exclude(exclude)
 	}
+}
 
-	if span == nil || !span.Remain() {
-		return []Out{}
+// Run with keeping only the results for which the function returns true. It is combined with the other filters.
+func WithKeep[Out any](keep func(Out) bool) Option[Out] {
+	return func(opts *Options[Out]) {
+		opts.exclude(func(out Out) bool {
+			return !keep(out)
+		})
 	}
+}
 
-	target := span.Clone()
-	if len(inputs) == 0 {
-		return []Out{mapout(target.ToSlot())}
+// Run with dropping the Slots shorter than the duration. It is evaluated on each Slot before mapping.
+func WithMinDuration[Out any](d time.Duration) Option[Out] {
+	return func(opts *Options[Out]) {
+		opts.MinDuration = d
 	}
+}
 
-	sort.Slice(inputs, func(i, j int) bool {
-		return inputs[i].Start().Before(inputs[j].Start())
-	})
+// Run with splitting the Slots longer than the duration into consecutive Slots of the duration and the remainder. It is evaluated on each Slot before mapping.
+// Combined with WithMinDuration, the remainder shorter than the minimum is dropped.
+func WithMaxDuration[Out any](d time.Duration) Option[Out] {
+	return func(opts *Options[Out]) {
+		opts.MaxDuration = d
+	}
+}
 
-	j := 0
-	slots := make([]Out, len(inputs)+1)
-	for _, input := range inputs {
-		block := mapin(input)
-		
-		if block.Contains(target) {
-			target.Drop()
-			break
-		}
+// Run with the start of each Slot rounded up and the end rounded down to the grid from the anchor. (e.g. every 15 minutes)
+// If the anchor is zero, midnight of the day in the location of each Slot is used. The Slots which become empty are dropped.
+// It is applied before WithMaxDuration and WithMinDuration.
+func WithAlignment[Out any](grid time.Duration, anchor time.Time) Option[Out] {
+	return func(opts *Options[Out]) {
+		opts.Grid = grid
+		opts.Anchor = anchor
+	}
+}
 
-		if block.OverlapAtStart(target) {
-			target.Shorten(block)
-			continue
-		}
+// Run with the Span clipped to the period which the Policy allows to book at the current time.
+func WithPolicy[Out any](policy *Policy) Option[Out] {
+	return func(opts *Options[Out]) {
+		opts.Policy = policy
+	}
+}
 
-		if block.IsContainedIn(target) {
-			slot := mapout(createSlotFrom(target, block))
-			target.Shorten(block)
-			if options.IsSetFilter() && options.FilterFunc(slot) {
-				continue
-			}
-			slots[j] = slot
-			j++
-			continue
+// Run with the buffer time added before and after every Block. (e.g. cleanup time after a reservation)
+// A buffer set to a Block itself takes precedence over this option.
+func WithBuffer[Out any](before, after time.Duration) Option[Out] {
+	return func(opts *Options[Out]) {
+		opts.Buffer = &Buffer{
+			Before: before,
+			After:  after,
 		}
+	}
+}
 
-		if block.OverlapAtEnd(target) {
-			slot := mapout(createSlotFrom(target, block))
-			target.Drop()
-			if options.IsSetFilter() && options.FilterFunc(slot) {
-				break
-			}
-			slots[j] = slot
-			j++
-			break
-		}
+// Run with sorting the given slice in place. Note that the order of your slice is changed.
+func WithInPlaceSort[Out any]() Option[Out] {
+	return func(opts *Options[Out]) {
+		opts.Sort = SortInPlace
 	}
+}
 
-	if !target.Remain() {
-		return slots[:j]
+// Run without sorting when the given slice is already sorted by the start time.
+func WithPresorted[Out any]() Option[Out] {
+	return func(opts *Options[Out]) {
+		opts.Sort = Presorted
 	}
-	slot := mapout(target.ToSlot())
-	if options.IsSetFilter() && options.FilterFunc(slot) {
-		return slots[:j]
+}
+
+// Run with the results expressed in the location. By default they are in the location of the Span, whatever the locations of the blocks are.
+func WithLocation[Out any](loc *time.Location) Option[Out] {
+	return func(opts *Options[Out]) {
+		opts.Location = loc
 	}
-	slots[j] = slot
-	j++
-	return slots[:j]
 }
 
-// It returns a list of available time slots.
-// Use this when passing and returning the pre-defined struct.
-func Find(blocks []*Block, span *Span, opts ...Option[*Slot]) []*Slot {
-	options := Options[*Slot]{
+func newOptions[Out any](opts []Option[Out]) *Options[Out] {
+	options := &Options[Out]{
 		FilterFunc: nil,
+		Buffer:     nil,
+		Sort:       SortCopy,
+		Location:   nil,
 	}
 	for _, opt := range opts {
-		opt(&options)
+		opt(options)
 	}
+	return options
+}
 
-	if span == nil || !span.Remain() {
-		return []*Slot{}
+// Sort the periods by the start time in the given mode.
+func sortPeriods[T Period](periods []T, mode SortMode) []T {
+	less := func(i, j int) bool {
+		return periods[i].Start().Before(periods[j].Start())
 	}
 
-	target := span.Clone()
-	if len(blocks) == 0 {
-		return []*Slot{target.ToSlot()}
+	switch mode {
+	case SortInPlace:
+		sort.Slice(periods, less)
+		return periods
+	case Presorted:
+		if sort.SliceIsSorted(periods, less) {
+			return periods
+		}
 	}
 
-	sort.Slice(blocks, func(i, j int) bool {
-		return blocks[i].Start().Before(blocks[j].Start())
+	periods = slice.Clone(periods)
+	sort.Slice(periods, less)
+	return periods
+}
+
+// Calculate available time slots (Slot). Provide the scheduled block (Block) and the target period (Span).
+// Use this when passing and returning your struct.
+// The given inputs are not modified unless WithInPlaceSort is set.
+func FindWithMapper[In Period, Out any](inputs []In, span *Span, mapin MapInFunc[In], mapout MapOutFunc[Out], opts ...Option[Out]) []Out {
+	options := newOptions(opts)
+
+	inputs = sortPeriods(inputs, options.Sort)
+
+	blocks := make([]*Block, len(inputs))
+	for i, input := range inputs {
+		blocks[i] = mapin(input)
+	}
+	return search(pad(blocks, options.Buffer), span, mapout, options)
+}
+
+// It returns a list of available time slots.
+// Use this when passing and returning the pre-defined struct.
+// The given blocks are not modified unless WithInPlaceSort is set.
+func Find(blocks []*Block, span *Span, opts ...Option[*Slot]) []*Slot {
+	options := newOptions(opts)
+
+	blocks = sortPeriods(blocks, options.Sort)
+	return search(pad(blocks, options.Buffer), span, func(s *Slot) *Slot { return s }, options)
+}
+
+// Collect the time slots from the blocks sorted by the start time, applying mapout and the filter.
+func search[Out any](blocks []*Block, span *Span, mapout MapOutFunc[Out], options *Options[Out]) []Out {
+	slots := make([]Out, 0, len(blocks)+1)
+	span = options.clip(span)
+	// The blocks are sorted here, so scan never fails.
+	_ = scan(slices.Values(blocks), span, options.location(span), func(s *Slot) bool {
+		return options.constrain(s, func(s *Slot) bool {
+			slot := mapout(s)
+			if options.IsSetFilter() && options.FilterFunc(slot) {
+				return true
+			}
+			slots = append(slots, slot)
+			return true
+		})
 	})
+	return slots
+}
+
+// Scan the blocks sorted by the start time, and yield the time not covered by them within the Span.
+// It stops when yield returns false, or returns ErrUnsortedBlocks when a block starts before the previous one.
+func scan(blocks iter.Seq[*Block], span *Span, loc *time.Location, yield func(*Slot) bool) error {
+	if span == nil || !span.Remain() {
+		return nil
+	}
+	if loc != nil {
+		next := yield
+		yield = func(slot *Slot) bool {
+			slot.localize(loc)
+			return next(slot)
+		}
+	}
+
+	target := span.Clone()
+	var previous *Block
+	for block := range blocks {
+		if previous != nil && block.start.Before(previous.start) {
+			return ErrUnsortedBlocks
+		}
+		previous = block
 
-	j := 0
-	slots := make([]*Slot, len(blocks)+1)
-	for _, block := range blocks {
-		
 		if block.Contains(target) {
 			target.Drop()
 			break
@@ -146,34 +346,23 @@ func Find(blocks []*Block, span *Span, opts ...Option[*Slot]) []*Slot {
 		if block.IsContainedIn(target) {
 			slot := createSlotFrom(target, block)
 			target.Shorten(block)
-			if options.IsSetFilter() && options.FilterFunc(slot) {
-				continue
+			if !yield(slot) {
+				return nil
 			}
-			slots[j] = slot
-			j++
 			continue
 		}
 
 		if block.OverlapAtEnd(target) {
 			slot := createSlotFrom(target, block)
 			target.Drop()
-			if options.IsSetFilter() && options.FilterFunc(slot) {
-				break
-			}
-			slots[j] = slot
-			j++
-			break
+			yield(slot)
+			return nil
 		}
 	}
 
 	if !target.Remain() {
-		return slots[:j]
-	}
-	slot := target.ToSlot()
-	if options.IsSetFilter() && options.FilterFunc(slot) {
-		return slots[:j]
+		return nil
 	}
-	slots[j] = slot
-	j++
-	return slots[:j]
+	yield(target.ToSlot())
+	return nil
 }
@@ -1,6 +1,7 @@
 package timeslots_test
 
 import (
+	"slices"
 	"timeslots"
 	"timeslots/internal/slice"
 	"testing"
@@ -277,3 +278,343 @@ func BenchmarkFindWithMapper(b *testing.B) {
 		}
 	}
 }
+
+func TestFindWithBuffer(t *testing.T) {
+	h := NewTestingHelper(now)
+
+	tests := []struct {
+		name   string
+		blocks []*timeslots.Block
+		search *timeslots.Span
+		before time.Duration
+		after  time.Duration
+		want   []*timeslots.Slot
+	}{
+		{
+			name:   "No buffer",
+			blocks: []*timeslots.Block{h.Block(2, 3)},
+			search: h.Span(0, 8),
+			want:   []*timeslots.Slot{h.Slot(0, 2), h.Slot(3, 8)},
+		},
+		{
+			name:   "Buffer before and after",
+			blocks: []*timeslots.Block{h.Block(2, 3)},
+			search: h.Span(0, 8),
+			before: 1 * time.Hour,
+			after:  2 * time.Hour,
+			want:   []*timeslots.Slot{h.Slot(0, 1), h.Slot(5, 8)},
+		},
+		{
+			name:   "Buffers make blocks overlap",
+			blocks: []*timeslots.Block{h.Block(2, 3), h.Block(4, 5)},
+			search: h.Span(0, 8),
+			after:  1 * time.Hour,
+			want:   []*timeslots.Slot{h.Slot(0, 2), h.Slot(6, 8)},
+		},
+		{
+			name:   "Buffer overridden by block",
+			blocks: []*timeslots.Block{h.Block(2, 3).WithBuffer(0, 0), h.Block(5, 6)},
+			search: h.Span(0, 8),
+			after:  1 * time.Hour,
+			want:   []*timeslots.Slot{h.Slot(0, 2), h.Slot(3, 5), h.Slot(7, 8)},
+		},
+		{
+			name:   "Buffer overridden by block changes order",
+			blocks: []*timeslots.Block{h.Block(1, 2), h.Block(3, 4).WithBuffer(3*time.Hour, 0)},
+			search: h.Span(0, 8),
+			want:   []*timeslots.Slot{h.Slot(4, 8)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := timeslots.Find(tt.blocks, tt.search, timeslots.WithBuffer[*timeslots.Slot](tt.before, tt.after))
+			if !slice.Equal(got, tt.want) {
+				t.Errorf("Find() got: %v, want: %v", slice.String(got), slice.String(tt.want))
+			}
+
+			mapIn := func(b *timeslots.Block) *timeslots.Block {
+				return b
+			}
+			mapOut := func(s *timeslots.Slot) *timeslots.Slot {
+				return s
+			}
+			got = timeslots.FindWithMapper(tt.blocks, tt.search, mapIn, mapOut, timeslots.WithBuffer[*timeslots.Slot](tt.before, tt.after))
+			if !slice.Equal(got, tt.want) {
+				t.Errorf("FindWithMapper() got: %v, want: %v", slice.String(got), slice.String(tt.want))
+			}
+		})
+	}
+}
+
+func TestFindSortMode(t *testing.T) {
+	h := NewTestingHelper(now)
+	want := []*timeslots.Slot{h.Slot(0, 1), h.Slot(2, 3), h.Slot(4, 8)}
+
+	mapIn := func(b *timeslots.Block) *timeslots.Block {
+		return b
+	}
+	mapOut := func(s *timeslots.Slot) *timeslots.Slot {
+		return s
+	}
+
+	tests := []struct {
+		name       string
+		blocks     []*timeslots.Block
+		opt        timeslots.Option[*timeslots.Slot]
+		wantSorted bool
+	}{
+		{
+			name:       "Input is left untouched by default",
+			blocks:     []*timeslots.Block{h.Block(3, 4), h.Block(1, 2)},
+			opt:        nil,
+			wantSorted: false,
+		},
+		{
+			name:       "Input is sorted in place",
+			blocks:     []*timeslots.Block{h.Block(3, 4), h.Block(1, 2)},
+			opt:        timeslots.WithInPlaceSort[*timeslots.Slot](),
+			wantSorted: true,
+		},
+		{
+			name:       "Presorted input",
+			blocks:     []*timeslots.Block{h.Block(1, 2), h.Block(3, 4)},
+			opt:        timeslots.WithPresorted[*timeslots.Slot](),
+			wantSorted: true,
+		},
+		{
+			name:       "Presorted input which is not sorted",
+			blocks:     []*timeslots.Block{h.Block(3, 4), h.Block(1, 2)},
+			opt:        timeslots.WithPresorted[*timeslots.Slot](),
+			wantSorted: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []timeslots.Option[*timeslots.Slot]{}
+			if tt.opt != nil {
+				opts = append(opts, tt.opt)
+			}
+
+			for name, find := range map[string]func([]*timeslots.Block) []*timeslots.Slot{
+				"Find": func(blocks []*timeslots.Block) []*timeslots.Slot {
+					return timeslots.Find(blocks, h.Span(0, 8), opts...)
+				},
+				"FindWithMapper": func(blocks []*timeslots.Block) []*timeslots.Slot {
+					return timeslots.FindWithMapper(blocks, h.Span(0, 8), mapIn, mapOut, opts...)
+				},
+			} {
+				blocks := slice.Clone(tt.blocks)
+				got := find(blocks)
+				if !slice.Equal(got, want) {
+					t.Errorf("%s got: %v, want: %v", name, slice.String(got), slice.String(want))
+				}
+				sorted := blocks[0].Start().Before(blocks[1].Start())
+				if sorted != tt.wantSorted {
+					t.Errorf("%s input sorted = %v, want %v", name, sorted, tt.wantSorted)
+				}
+			}
+		})
+	}
+}
+
+func TestFindLocation(t *testing.T) {
+	tokyo := time.FixedZone("Asia/Tokyo", 9*60*60)
+	newYork := time.FixedZone("America/New_York", -5*60*60)
+	base := time.Date(2024, 9, 26, 0, 0, 0, 0, time.UTC)
+	at := func(hour int) time.Time {
+		return base.Add(time.Duration(hour) * time.Hour)
+	}
+	slot := func(start, end int) *timeslots.Slot {
+		s, _ := timeslots.NewSlot(at(start), at(end))
+		return s
+	}
+
+	span, _ := timeslots.NewSpanInLocation(at(0), at(8), tokyo)
+	blocks := []*timeslots.Block{
+		timeslots.NewBlockWithoutValidating(at(1), at(2)),
+		timeslots.NewBlockWithoutValidating(at(3).In(newYork), at(4).In(newYork)),
+	}
+
+	tests := []struct {
+		name string
+		opts []timeslots.Option[*timeslots.Slot]
+		want *time.Location
+	}{
+		{
+			name: "Location of the Span by default",
+			opts: nil,
+			want: tokyo,
+		},
+		{
+			name: "Requested location",
+			opts: []timeslots.Option[*timeslots.Slot]{timeslots.WithLocation[*timeslots.Slot](time.UTC)},
+			want: time.UTC,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := timeslots.Find(blocks, span, tt.opts...)
+			want := []*timeslots.Slot{slot(0, 1), slot(2, 3), slot(4, 8)}
+			if !slice.Equal(got, want) {
+				t.Fatalf("got: %v, want: %v", slice.String(got), slice.String(want))
+			}
+			for _, s := range got {
+				if s.Start().Location() != tt.want || s.End().Location() != tt.want {
+					t.Errorf("slot %v is in %v, %v, want %v", s, s.Start().Location(), s.End().Location(), tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestFindDuration(t *testing.T) {
+	h := NewTestingHelper(now)
+	blocks := []*timeslots.Block{h.Block(1, 2), h.Block(4, 5)}
+
+	tests := []struct {
+		name string
+		opts []timeslots.Option[*timeslots.Slot]
+		want []*timeslots.Slot
+	}{
+		{
+			name: "Minimum duration",
+			opts: []timeslots.Option[*timeslots.Slot]{timeslots.WithMinDuration[*timeslots.Slot](2 * time.Hour)},
+			want: []*timeslots.Slot{h.Slot(2, 4), h.Slot(5, 8)},
+		},
+		{
+			name: "Maximum duration",
+			opts: []timeslots.Option[*timeslots.Slot]{timeslots.WithMaxDuration[*timeslots.Slot](2 * time.Hour)},
+			want: []*timeslots.Slot{h.Slot(0, 1), h.Slot(2, 4), h.Slot(5, 7), h.Slot(7, 8)},
+		},
+		{
+			name: "Remainder shorter than the minimum is dropped",
+			opts: []timeslots.Option[*timeslots.Slot]{
+				timeslots.WithMinDuration[*timeslots.Slot](2 * time.Hour),
+				timeslots.WithMaxDuration[*timeslots.Slot](2 * time.Hour),
+			},
+			want: []*timeslots.Slot{h.Slot(2, 4), h.Slot(5, 7)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := timeslots.Find(blocks, h.Span(0, 8), tt.opts...)
+			if !slice.Equal(got, tt.want) {
+				t.Errorf("Find got: %v, want: %v", slice.String(got), slice.String(tt.want))
+			}
+
+			var streamed []*timeslots.Slot
+			for slot := range timeslots.FindSeq(slices.Values(blocks), h.Span(0, 8), tt.opts...) {
+				streamed = append(streamed, slot)
+			}
+			if !slice.Equal(streamed, tt.want) {
+				t.Errorf("FindSeq got: %v, want: %v", slice.String(streamed), slice.String(tt.want))
+			}
+		})
+	}
+}
+
+func TestFindKeepExclude(t *testing.T) {
+	h := NewTestingHelper(now)
+	blocks := []*timeslots.Block{h.Block(1, 2), h.Block(4, 5)}
+
+	type Free struct {
+		Start time.Time
+		Hours float64
+	}
+	mapIn := func(b *timeslots.Block) *timeslots.Block {
+		return b
+	}
+	mapOut := func(s *timeslots.Slot) *Free {
+		return &Free{Start: s.Start(), Hours: s.End().Sub(s.Start()).Hours()}
+	}
+
+	got := timeslots.FindWithMapper(blocks, h.Span(0, 8), mapIn, mapOut,
+		timeslots.WithKeep(func(f *Free) bool { return f.Hours >= 2 }),
+		timeslots.WithExclude(func(f *Free) bool { return f.Start.Equal(now.Add(2 * time.Hour)) }),
+	)
+	if len(got) != 1 || !got[0].Start.Equal(now.Add(5*time.Hour)) || got[0].Hours != 3 {
+		t.Errorf("got: %+v, want a free time of 3 hours from 5", got)
+	}
+}
+
+func TestFindAlignment(t *testing.T) {
+	kolkata := time.FixedZone("Asia/Kolkata", 5*60*60+30*60)
+	at := func(hour, minute, second int) time.Time {
+		return time.Date(2024, 9, 26, hour, minute, second, 0, kolkata)
+	}
+	slot := func(start, end time.Time) *timeslots.Slot {
+		s, _ := timeslots.NewSlot(start, end)
+		return s
+	}
+
+	span, _ := timeslots.NewSpan(at(8, 3, 27), at(18, 0, 0))
+	blocks := []*timeslots.Block{
+		timeslots.NewBlockWithoutValidating(at(9, 41, 0), at(10, 7, 0)),
+		timeslots.NewBlockWithoutValidating(at(10, 20, 0), at(12, 2, 0)),
+	}
+
+	tests := []struct {
+		name string
+		opts []timeslots.Option[*timeslots.Slot]
+		want []*timeslots.Slot
+	}{
+		{
+			name: "15 minutes from midnight in the location of the Span",
+			opts: []timeslots.Option[*timeslots.Slot]{timeslots.WithAlignment[*timeslots.Slot](15*time.Minute, time.Time{})},
+			want: []*timeslots.Slot{
+				slot(at(8, 15, 0), at(9, 30, 0)),
+				slot(at(12, 15, 0), at(18, 0, 0)),
+			},
+		},
+		{
+			name: "Hours from the anchor",
+			opts: []timeslots.Option[*timeslots.Slot]{timeslots.WithAlignment[*timeslots.Slot](time.Hour, at(0, 30, 0))},
+			want: []*timeslots.Slot{
+				slot(at(8, 30, 0), at(9, 30, 0)),
+				slot(at(12, 30, 0), at(17, 30, 0)),
+			},
+		},
+		{
+			name: "Aligned before the maximum duration",
+			opts: []timeslots.Option[*timeslots.Slot]{
+				timeslots.WithAlignment[*timeslots.Slot](time.Hour, time.Time{}),
+				timeslots.WithMaxDuration[*timeslots.Slot](2 * time.Hour),
+			},
+			want: []*timeslots.Slot{
+				slot(at(13, 0, 0), at(15, 0, 0)),
+				slot(at(15, 0, 0), at(17, 0, 0)),
+				slot(at(17, 0, 0), at(18, 0, 0)),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := timeslots.Find(blocks, span, tt.opts...)
+			if !slice.Equal(got, tt.want) {
+				t.Errorf("got: %v, want: %v", slice.String(got), slice.String(tt.want))
+			}
+		})
+	}
+}
+
+func TestFindFiltersCompose(t *testing.T) {
+	h := NewTestingHelper(now)
+	blocks := []*timeslots.Block{h.Block(1, 2), h.Block(4, 5)}
+	keep := timeslots.WithKeep(func(s *timeslots.Slot) bool { return s.End().Sub(s.Start()) >= 2*time.Hour })
+	filter := timeslots.WithFilter(func(s *timeslots.Slot) bool { return s.Start().Equal(now.Add(5 * time.Hour)) })
+	want := []*timeslots.Slot{h.Slot(2, 4)}
+
+	for name, opts := range map[string][]timeslots.Option[*timeslots.Slot]{
+		"Keep then filter": {keep, filter},
+		"Filter then keep": {filter, keep},
+	} {
+		if got := timeslots.Find(blocks, h.Span(0, 8), opts...); !slice.Equal(got, want) {
+			t.Errorf("%s got: %v, want: %v", name, slice.String(got), slice.String(want))
+		}
+	}
+}
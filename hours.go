@@ -0,0 +1,155 @@
+package timeslots
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"timeslots/internal/tz"
+)
+
+// This is a time of day on the wall clock. 24:00 represents the end of the day.
+type Clock struct {
+	hour   int
+	minute int
+	second int
+}
+
+// Creates a new Clock. It verifies the range of each value.
+func NewClock(hour, minute, second int) (Clock, error) {
+	if hour < 0 || minute < 0 || minute > 59 || second < 0 || second > 59 {
+		return Clock{}, fmt.Errorf("invalid clock arguments")
+	}
+	if hour > 24 || (hour == 24 && (minute > 0 || second > 0)) {
+		return Clock{}, fmt.Errorf("invalid clock arguments")
+	}
+	return Clock{hour: hour, minute: minute, second: second}, nil
+}
+
+// Parse the time of day such as "09:00" or "09:00:30". "24:00" represents the end of the day.
+func ParseClock(s string) (Clock, error) {
+	if s == "24:00" || s == "24:00:00" {
+		return NewClock(24, 0, 0)
+	}
+	for _, layout := range []string{time.TimeOnly, "15:04"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return NewClock(t.Hour(), t.Minute(), t.Second())
+		}
+	}
+	return Clock{}, fmt.Errorf("invalid clock format: %q", s)
+}
+
+// Represents the time of day as a string.
+func (c Clock) String() string {
+	return fmt.Sprintf("%02d:%02d:%02d", c.hour, c.minute, c.second)
+}
+
+// Whether the Clock is earlier than the given Clock.
+func (c Clock) Before(other Clock) bool {
+	return c.seconds() < other.seconds()
+}
+
+func (c Clock) seconds() int {
+	return c.hour*3600 + c.minute*60 + c.second
+}
+
+// The instant of the Clock on the given date in the location. A Clock skipped by DST is shifted forward, and a repeated one is the first occurrence.
+func (c Clock) on(year int, month time.Month, day int, loc *time.Location) time.Time {
+	return tz.Date(year, month, day, c.hour, c.minute, c.second, loc)
+}
+
+type hours struct {
+	open  Clock
+	close Clock
+}
+
+// This is the weekly opening hours in a location. (e.g. Mon–Fri 09:00–18:00, Sat 10:00–14:00, closed on Sunday)
+type OpeningHours struct {
+	location *time.Location
+	week     [7][]hours
+}
+
+// Creates new OpeningHours, closed on every day. The hours are interpreted on the wall clock of the location.
+func NewOpeningHours(loc *time.Location) *OpeningHours {
+	if loc == nil {
+		loc = time.Local
+	}
+	return &OpeningHours{
+		location: loc,
+	}
+}
+
+// Add hours open on the day of the week. If the closing time is not after the opening time, it closes on the next day.
+func (o *OpeningHours) Add(day time.Weekday, open, close Clock) *OpeningHours {
+	o.week[day] = append(o.week[day], hours{open: open, close: close})
+	return o
+}
+
+// The location of the OpeningHours.
+func (o *OpeningHours) Location() *time.Location {
+	return o.location
+}
+
+// Calculate the open windows within the Span. Each day is evaluated on the wall clock, so the windows follow the DST transitions.
+// Overlapping and adjacent windows are merged.
+func (o *OpeningHours) Windows(span *Span) []*Span {
+	if span == nil || !span.Remain() {
+		return []*Span{}
+	}
+
+	year, month, day := span.start.In(o.location).Date()
+	lastYear, lastMonth, lastDay := span.end.In(o.location).Date()
+	last := time.Date(lastYear, lastMonth, lastDay, 12, 0, 0, 0, o.location)
+
+	windows := []*Span{}
+	for date := time.Date(year, month, day-1, 12, 0, 0, 0, o.location); !date.After(last); date = date.AddDate(0, 0, 1) {
+		y, m, d := date.Date()
+		for _, h := range o.week[date.Weekday()] {
+			start := h.open.on(y, m, d, o.location)
+			end := h.close.on(y, m, d, o.location)
+			if !h.open.Before(h.close) {
+				end = h.close.on(y, m, d+1, o.location)
+			}
+
+			if start.Before(span.start) {
+				start = span.start
+			}
+			if end.After(span.end) {
+				end = span.end
+			}
+			if start.Before(end) {
+				windows = append(windows, newSpan(start, end))
+			}
+		}
+	}
+
+	sort.Slice(windows, func(i, j int) bool {
+		return windows[i].start.Before(windows[j].start)
+	})
+	j := 0
+	for _, window := range windows {
+		if j > 0 && beforeEq(window.start, windows[j-1].end) {
+			if window.end.After(windows[j-1].end) {
+				windows[j-1].end = window.end
+			}
+			continue
+		}
+		windows[j] = window
+		j++
+	}
+	return windows[:j]
+}
+
+// It returns a list of available time slots within the open windows of the OpeningHours.
+func FindWithinHours(blocks []*Block, span *Span, hours *OpeningHours, opts ...Option[*Slot]) []*Slot {
+	options := newOptions(opts)
+	blocks = sortPeriods(blocks, options.Sort)
+	opts = append(slices.Clip(opts), WithPresorted[*Slot]())
+
+	slots := []*Slot{}
+	for _, window := range hours.Windows(span) {
+		slots = append(slots, Find(blocks, window, opts...)...)
+	}
+	return slots
+}
@@ -0,0 +1,189 @@
+package timeslots_test
+
+import (
+	"testing"
+	"time"
+	"timeslots"
+	"timeslots/internal/slice"
+)
+
+func clock(s string) timeslots.Clock {
+	c, err := timeslots.ParseClock(s)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func TestParseClock(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "09:00", want: "09:00:00"},
+		{input: "23:59:59", want: "23:59:59"},
+		{input: "24:00", want: "24:00:00"},
+		{input: "24:01", wantErr: true},
+		{input: "12:60", wantErr: true},
+		{input: "noon", wantErr: true},
+		{input: "09:00x", wantErr: true},
+		{input: "09:00:30abc", wantErr: true},
+		{input: "09:00:", wantErr: true},
+		{input: " 09:00", wantErr: true},
+		{input: "24:00:01", wantErr: true},
+		{input: "09:00:30", want: "09:00:30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := timeslots.ParseClock(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseClock() error = %v, wantErr = %v", err, tt.wantErr)
+			}
+			if err == nil && got.String() != tt.want {
+				t.Errorf("ParseClock() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpeningHoursWindows(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	at := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2024, month, day, hour, 0, 0, 0, newYork)
+	}
+	span := func(start, end time.Time) *timeslots.Span {
+		s, _ := timeslots.NewSpan(start, end)
+		return s
+	}
+	slot := func(start, end time.Time) *timeslots.Slot {
+		s, _ := timeslots.NewSlot(start, end)
+		return s
+	}
+
+	weekdays := timeslots.NewOpeningHours(newYork)
+	for _, day := range []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday} {
+		weekdays.Add(day, clock("09:00"), clock("18:00"))
+	}
+	weekdays.Add(time.Saturday, clock("10:00"), clock("14:00"))
+
+	overnight := timeslots.NewOpeningHours(newYork).
+		Add(time.Saturday, clock("22:00"), clock("06:00")).
+		Add(time.Sunday, clock("06:00"), clock("08:00"))
+
+	transition := timeslots.NewOpeningHours(newYork).
+		Add(time.Sunday, clock("01:30"), clock("02:30"))
+
+	tests := []struct {
+		name   string
+		hours  *timeslots.OpeningHours
+		search *timeslots.Span
+		want   []*timeslots.Slot
+	}{
+		{
+			name:   "Nil span",
+			hours:  weekdays,
+			search: nil,
+			want:   []*timeslots.Slot{},
+		},
+		{
+			name:   "Friday to Monday",
+			hours:  weekdays,
+			search: span(at(time.March, 8, 0), at(time.March, 11, 12)),
+			want: []*timeslots.Slot{
+				slot(at(time.March, 8, 9), at(time.March, 8, 18)),
+				slot(at(time.March, 9, 10), at(time.March, 9, 14)),
+				slot(at(time.March, 11, 9), at(time.March, 11, 12)),
+			},
+		},
+		{
+			name:   "Overnight across spring forward is merged with the next window",
+			hours:  overnight,
+			search: span(at(time.March, 9, 0), at(time.March, 11, 0)),
+			want: []*timeslots.Slot{
+				slot(at(time.March, 9, 22), at(time.March, 10, 8)),
+			},
+		},
+		{
+			name:   "Overnight across fall back",
+			hours:  overnight,
+			search: span(at(time.November, 2, 23), at(time.November, 3, 7)),
+			want: []*timeslots.Slot{
+				slot(at(time.November, 2, 23), at(time.November, 3, 7)),
+			},
+		},
+		{
+			name:   "Closing time in the spring forward gap is shifted forward",
+			hours:  transition,
+			search: span(at(time.March, 10, 0), at(time.March, 10, 12)),
+			want: []*timeslots.Slot{
+				slot(at(time.March, 10, 0).Add(90*time.Minute), at(time.March, 10, 3).Add(30*time.Minute)),
+			},
+		},
+		{
+			name:   "Opening time in the fall back overlap is the first occurrence",
+			hours:  transition,
+			search: span(at(time.November, 3, 0), at(time.November, 3, 12)),
+			want: []*timeslots.Slot{
+				slot(at(time.November, 3, 0).Add(90*time.Minute), at(time.November, 3, 0).Add(210*time.Minute)),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			windows := tt.hours.Windows(tt.search)
+			got := make([]*timeslots.Slot, len(windows))
+			for i, w := range windows {
+				got[i] = w.ToSlot()
+			}
+			if !slice.Equal(got, tt.want) {
+				t.Errorf("got: %v, want: %v", slice.String(got), slice.String(tt.want))
+			}
+		})
+	}
+
+	duration := totalDuration(overnight.Windows(span(at(time.March, 9, 0), at(time.March, 10, 7))))
+	if duration != 8*time.Hour {
+		t.Errorf("overnight window lasts %v, want %v", duration, 8*time.Hour)
+	}
+}
+
+func totalDuration(windows []*timeslots.Span) time.Duration {
+	var d time.Duration
+	for _, w := range windows {
+		d += w.End().Sub(w.Start())
+	}
+	return d
+}
+
+func TestFindWithinHours(t *testing.T) {
+	loc := time.UTC
+	at := func(day, hour int) time.Time {
+		return time.Date(2024, 9, day, hour, 0, 0, 0, loc)
+	}
+	block := func(start, end time.Time) *timeslots.Block {
+		return timeslots.NewBlockWithoutValidating(start, end)
+	}
+	slot := func(start, end time.Time) *timeslots.Slot {
+		s, _ := timeslots.NewSlot(start, end)
+		return s
+	}
+
+	hours := timeslots.NewOpeningHours(loc).
+		Add(time.Monday, clock("09:00"), clock("18:00")).
+		Add(time.Tuesday, clock("09:00"), clock("18:00"))
+	span, _ := timeslots.NewSpan(at(22, 0), at(25, 0))
+	blocks := []*timeslots.Block{block(at(23, 12), at(23, 13)), block(at(24, 17), at(24, 20))}
+
+	got := timeslots.FindWithinHours(blocks, span, hours)
+	want := []*timeslots.Slot{
+		slot(at(23, 9), at(23, 12)),
+		slot(at(23, 13), at(23, 18)),
+		slot(at(24, 9), at(24, 17)),
+	}
+	if !slice.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", slice.String(got), slice.String(want))
+	}
+}
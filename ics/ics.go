@@ -0,0 +1,82 @@
+// Package ics reads and writes iCalendar (RFC 5545) data as Blocks and Slots.
+package ics
+
+import (
+	"slices"
+	"time"
+
+	"timeslots"
+	"timeslots/recurrence"
+)
+
+// This is a VEVENT component.
+type Event struct {
+	UID         string
+	Stamp       time.Time
+	Summary     string
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+	Transparent bool
+	Cancelled   bool
+	Recurrence  *recurrence.Recurrence
+	// The original start time of the occurrence which this Event overrides. It is zero unless the Event overrides an occurrence of a recurring Event of the same UID.
+	RecurrenceID time.Time
+}
+
+// Whether the Event occupies the time. Transparent and cancelled events do not.
+func (e *Event) Busy() bool {
+	return !e.Transparent && !e.Cancelled
+}
+
+// Convert the Event into a Block. It can be used as the mapper of timeslots.NewBlocks. Recurrence is not expanded here.
+func (e *Event) Block() (*timeslots.Block, error) {
+	return timeslots.NewBlock(e.Start, e.End)
+}
+
+// This is a VCALENDAR object.
+type Calendar struct {
+	ProdID   string
+	Events   []*Event
+	FreeBusy []*FreeBusy
+	Errors   []*EventError
+}
+
+// Convert the busy Events into Blocks clipped to the Span. Recurring Events are expanded.
+// The occurrences overridden by the Events with RECURRENCE-ID of the same UID are replaced by them, and cancelled overrides remove the occurrences.
+func (c *Calendar) Blocks(span *timeslots.Span) []*timeslots.Block {
+	blocks := []*timeslots.Block{}
+	if span == nil {
+		return blocks
+	}
+
+	overridden := map[string][]time.Time{}
+	for _, event := range c.Events {
+		if !event.RecurrenceID.IsZero() {
+			overridden[event.UID] = append(overridden[event.UID], event.RecurrenceID)
+		}
+	}
+
+	for _, event := range c.Events {
+		if !event.Busy() {
+			continue
+		}
+		if event.Recurrence != nil && event.RecurrenceID.IsZero() {
+			r := *event.Recurrence
+			r.ExDates = append(slices.Clip(r.ExDates), overridden[event.UID]...)
+			blocks = append(blocks, r.Blocks(span)...)
+			continue
+		}
+		if event.Start.Before(span.End()) && event.End.After(span.Start()) {
+			start, end := event.Start, event.End
+			if start.Before(span.Start()) {
+				start = span.Start()
+			}
+			if end.After(span.End()) {
+				end = span.End()
+			}
+			blocks = append(blocks, timeslots.NewBlockWithoutValidating(start, end))
+		}
+	}
+	return blocks
+}
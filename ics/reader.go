@@ -0,0 +1,183 @@
+package ics
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"timeslots/internal/ical"
+	"timeslots/recurrence"
+)
+
+// This is an error of a VEVENT which could not be read. The other events are still read.
+type EventError struct {
+	Line int
+	UID  string
+	Err  error
+}
+
+func (e *EventError) Error() string {
+	if e.UID == "" {
+		return fmt.Sprintf("event at line %d: %v", e.Line, e.Err)
+	}
+	return fmt.Sprintf("event %q at line %d: %v", e.UID, e.Line, e.Err)
+}
+
+func (e *EventError) Unwrap() error {
+	return e.Err
+}
+
+// Read a VCALENDAR object. Floating times and DATE values are interpreted in the given location.
+// A broken VEVENT is reported in Calendar.Errors instead of failing the whole object.
+func Read(r io.Reader, loc *time.Location) (*Calendar, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+
+	lines, err := ical.ReadLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	calendar := &Calendar{
+		Events: []*Event{},
+		Errors: []*EventError{},
+	}
+	found := false
+	depth := 0
+	var event []*ical.Property
+	eventLine := 0
+	var eventErr error
+	for i, line := range lines {
+		property, err := ical.ParseProperty(line)
+		if err != nil {
+			if event != nil && eventErr == nil {
+				eventErr = err
+			}
+			continue
+		}
+
+		switch {
+		case property.Name == "BEGIN" && strings.EqualFold(property.Value, "VCALENDAR"):
+			found = true
+		case property.Name == "BEGIN" && strings.EqualFold(property.Value, "VEVENT") && event == nil:
+			event = []*ical.Property{}
+			eventLine = i + 1
+			eventErr = nil
+			depth = 0
+		case property.Name == "END" && strings.EqualFold(property.Value, "VEVENT") && event != nil && depth == 0:
+			e, err := readEvent(event, loc)
+			if eventErr != nil {
+				err = eventErr
+			}
+			if err != nil {
+				calendar.Errors = append(calendar.Errors, &EventError{Line: eventLine, UID: uid(event), Err: err})
+			} else {
+				calendar.Events = append(calendar.Events, e)
+			}
+			event = nil
+		case event != nil:
+			// Nested components such as VALARM are skipped.
+			switch property.Name {
+			case "BEGIN":
+				depth++
+			case "END":
+				depth--
+			default:
+				if depth == 0 {
+					event = append(event, property)
+				}
+			}
+		case property.Name == "PRODID":
+			calendar.ProdID = property.Value
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("VCALENDAR is not found")
+	}
+	if event != nil {
+		calendar.Errors = append(calendar.Errors, &EventError{Line: eventLine, UID: uid(event), Err: fmt.Errorf("END:VEVENT is not found")})
+	}
+	return calendar, nil
+}
+
+func uid(properties []*ical.Property) string {
+	for _, p := range properties {
+		if p.Name == "UID" {
+			return p.Value
+		}
+	}
+	return ""
+}
+
+func readEvent(properties []*ical.Property, loc *time.Location) (*Event, error) {
+	event := &Event{}
+	var duration time.Duration
+	hasEnd, hasDuration := false, false
+	recurring := []string{}
+	for _, p := range properties {
+		location, err := ical.Location(p.Params, loc)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", p.Name, err)
+		}
+
+		switch p.Name {
+		case "UID":
+			event.UID = p.Value
+		case "DTSTAMP":
+			event.Stamp, _, err = ical.ParseTime(p.Value, location)
+		case "SUMMARY":
+			event.Summary = ical.Unescape(p.Value)
+		case "DTSTART":
+			event.Start, event.AllDay, err = ical.ParseTime(p.Value, location)
+			recurring = append(recurring, p.String())
+		case "RECURRENCE-ID":
+			event.RecurrenceID, _, err = ical.ParseTime(p.Value, location)
+		case "DTEND":
+			event.End, _, err = ical.ParseTime(p.Value, location)
+			hasEnd = true
+		case "DURATION":
+			duration, err = ical.ParseDuration(p.Value)
+			hasDuration = true
+		case "TRANSP":
+			event.Transparent = strings.EqualFold(p.Value, "TRANSPARENT")
+		case "STATUS":
+			event.Cancelled = strings.EqualFold(p.Value, "CANCELLED")
+		case "RRULE", "RDATE", "EXDATE":
+			recurring = append(recurring, p.String())
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", p.Name, err)
+		}
+	}
+
+	if event.Start.IsZero() {
+		return nil, fmt.Errorf("DTSTART is required")
+	}
+	switch {
+	case hasEnd && hasDuration:
+		return nil, fmt.Errorf("DTEND and DURATION must not occur together")
+	case hasDuration:
+		event.End = event.Start.Add(duration)
+	case !hasEnd && event.AllDay:
+		event.End = event.Start.AddDate(0, 0, 1)
+	case !hasEnd:
+		event.End = event.Start
+	}
+	if event.End.Before(event.Start) {
+		return nil, fmt.Errorf("DTEND is before DTSTART")
+	}
+
+	// An override of an occurrence is not expanded by itself.
+	if len(recurring) > 1 && event.RecurrenceID.IsZero() {
+		r, err := recurrence.ParseInLocation(strings.Join(recurring, "\n"), loc)
+		if err != nil {
+			return nil, err
+		}
+		r.Duration = event.End.Sub(event.Start)
+		event.Recurrence = r
+	}
+	return event, nil
+}
@@ -0,0 +1,198 @@
+package ics_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"timeslots"
+	"timeslots/ics"
+	"timeslots/recurrence"
+)
+
+const calendar = `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//Example//Calendar//EN
+BEGIN:VEVENT
+UID:meeting
+SUMMARY:Weekly\, meeting
+DTSTART;TZID=Asia/Tokyo:20240903T100000
+DTEND;TZID=Asia/Tokyo:20240903T110000
+RRULE:FREQ=WEEKLY;COUNT=3
+EXDATE;TZID=Asia/Tokyo:20240910T100000
+BEGIN:VALARM
+TRIGGER:-PT15M
+ACTION:DISPLAY
+END:VALARM
+END:VEVENT
+BEGIN:VEVENT
+UID:holiday
+DTSTART;VALUE=DATE:20240916
+DTEND;VALUE=DATE:20240917
+END:VEVENT
+BEGIN:VEVENT
+UID:lunch
+DTSTART:20240904T030000Z
+DURATION:PT1H
+TRANSP:TRANSPARENT
+END:VEVENT
+BEGIN:VEVENT
+UID:cancelled
+DTSTART:20240905T030000Z
+DTEND:20240905T040000Z
+STATUS:CANCELLED
+END:VEVENT
+BEGIN:VEVENT
+UID:broken
+DTSTART:2024-09-06
+END:VEVENT
+BEGIN:VEVENT
+UID:inverted
+DTSTART:20240906T040000Z
+DTEND:20240906T030000Z
+END:VEVENT
+BEGIN:VEVENT
+UID:floating
+DTSTART:20240906T090000
+DTEND:20240906T093000
+END:VEVENT
+END:VCALENDAR
+`
+
+func TestRead(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+
+	got, err := ics.Read(strings.NewReader(calendar), tokyo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.ProdID != "-//Example//Calendar//EN" {
+		t.Errorf("ProdID = %v", got.ProdID)
+	}
+	if len(got.Events) != 5 {
+		t.Fatalf("got %d events, want 5", len(got.Events))
+	}
+	if len(got.Errors) != 2 {
+		t.Fatalf("got %d errors, want 2", len(got.Errors))
+	}
+	for i, uid := range []string{"broken", "inverted"} {
+		if got.Errors[i].UID != uid {
+			t.Errorf("error[%d] UID = %v, want %v", i, got.Errors[i].UID, uid)
+		}
+		var eventErr *ics.EventError
+		if !errors.As(got.Errors[i], &eventErr) {
+			t.Errorf("error[%d] is not an EventError", i)
+		}
+	}
+
+	meeting := got.Events[0]
+	if meeting.Summary != "Weekly, meeting" || meeting.Recurrence == nil {
+		t.Errorf("meeting = %+v", meeting)
+	}
+	holiday := got.Events[1]
+	if !holiday.AllDay || !holiday.Start.Equal(time.Date(2024, 9, 16, 0, 0, 0, 0, tokyo)) || holiday.End.Sub(holiday.Start) != 24*time.Hour {
+		t.Errorf("holiday = %+v", holiday)
+	}
+	if got.Events[2].Busy() || got.Events[3].Busy() {
+		t.Errorf("transparent or cancelled event is busy")
+	}
+	floating := got.Events[4]
+	if !floating.Start.Equal(time.Date(2024, 9, 6, 9, 0, 0, 0, tokyo)) {
+		t.Errorf("floating = %+v", floating)
+	}
+
+	span, _ := timeslots.NewSpan(time.Date(2024, 9, 1, 0, 0, 0, 0, tokyo), time.Date(2024, 10, 1, 0, 0, 0, 0, tokyo))
+	blocks := got.Blocks(span)
+	want := []time.Time{
+		time.Date(2024, 9, 3, 10, 0, 0, 0, tokyo),
+		time.Date(2024, 9, 17, 10, 0, 0, 0, tokyo),
+		time.Date(2024, 9, 16, 0, 0, 0, 0, tokyo),
+		time.Date(2024, 9, 6, 9, 0, 0, 0, tokyo),
+	}
+	if len(blocks) != len(want) {
+		t.Fatalf("got %d blocks: %v, want %d", len(blocks), timeslots.ToString(blocks), len(want))
+	}
+	for i, w := range want {
+		if !blocks[i].Start().Equal(w) {
+			t.Errorf("block[%d] starts at %v, want %v", i, blocks[i].Start(), w)
+		}
+	}
+
+	events := []*ics.Event{floating}
+	if _, err := timeslots.NewBlocks(events, (*ics.Event).Block); err != nil {
+		t.Errorf("NewBlocks() error = %v", err)
+	}
+}
+
+func TestReadWithoutCalendar(t *testing.T) {
+	_, err := ics.Read(strings.NewReader("BEGIN:VEVENT\nEND:VEVENT\n"), time.UTC)
+	if err == nil {
+		t.Errorf("Read() error = nil, want error")
+	}
+}
+
+func TestCalendarBlocksClipped(t *testing.T) {
+	start := time.Date(2024, 9, 3, 10, 0, 0, 0, time.UTC)
+	rule, _ := recurrence.ParseRule("FREQ=DAILY;COUNT=2", time.UTC)
+	calendar := &ics.Calendar{
+		Events: []*ics.Event{
+			{UID: "single", Start: start, End: start.Add(3 * time.Hour)},
+			{
+				UID:        "daily",
+				Start:      start,
+				End:        start.Add(3 * time.Hour),
+				Recurrence: &recurrence.Recurrence{Start: start, Duration: 3 * time.Hour, Rules: []*recurrence.Rule{rule}},
+			},
+		},
+	}
+
+	span, _ := timeslots.NewSpan(start.Add(time.Hour), start.Add(2*time.Hour))
+	want := "2024-09-03 11:00:00, 2024-09-03 12:00:00\n" +
+		"2024-09-03 11:00:00, 2024-09-03 12:00:00\n"
+	if blocks := timeslots.ToString(calendar.Blocks(span)); blocks != want {
+		t.Errorf("Blocks() = %v, want %v", blocks, want)
+	}
+}
+
+func TestReadRecurrenceOverrides(t *testing.T) {
+	const overrides = `BEGIN:VCALENDAR
+VERSION:2.0
+BEGIN:VEVENT
+UID:x
+DTSTART:20240903T100000Z
+DTEND:20240903T110000Z
+RRULE:FREQ=DAILY;COUNT=3
+END:VEVENT
+BEGIN:VEVENT
+UID:x
+RECURRENCE-ID:20240903T100000Z
+DTSTART:20240903T150000Z
+DTEND:20240903T160000Z
+END:VEVENT
+BEGIN:VEVENT
+UID:x
+RECURRENCE-ID:20240904T100000Z
+DTSTART:20240904T100000Z
+DTEND:20240904T110000Z
+STATUS:CANCELLED
+END:VEVENT
+END:VCALENDAR
+`
+	got, err := ics.Read(strings.NewReader(overrides), time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Errors) != 0 || len(got.Events) != 3 || got.Events[1].Recurrence != nil {
+		t.Fatalf("got %d events and %v errors", len(got.Events), got.Errors)
+	}
+
+	span, _ := timeslots.NewSpan(time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC))
+	// The occurrences of the master come first, followed by the overrides.
+	want := "2024-09-05 10:00:00, 2024-09-05 11:00:00\n" +
+		"2024-09-03 15:00:00, 2024-09-03 16:00:00\n"
+	if blocks := timeslots.ToString(got.Blocks(span)); blocks != want {
+		t.Errorf("Blocks() = %v, want %v", blocks, want)
+	}
+}
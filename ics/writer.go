@@ -0,0 +1,197 @@
+package ics
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"timeslots"
+	"timeslots/internal/ical"
+)
+
+const defaultProdID = "-//timeslots//timeslots//EN"
+
+// This is a VFREEBUSY component. Free, Busy and Tentative are written as FREEBUSY properties with FBTYPE of FREE, BUSY and BUSY-TENTATIVE.
+type FreeBusy struct {
+	UID       string
+	Stamp     time.Time
+	Start     time.Time
+	End       time.Time
+	Free      []*timeslots.Slot
+	Busy      []*timeslots.Block
+	Tentative []*timeslots.Block
+}
+
+// Creates a new FreeBusy from the result of timeslots.Find. The Span is used as DTSTART and DTEND.
+func NewFreeBusy(uid string, span *timeslots.Span, free []*timeslots.Slot, busy []*timeslots.Block) *FreeBusy {
+	return &FreeBusy{
+		UID:   uid,
+		Stamp: time.Now(),
+		Start: span.Start(),
+		End:   span.End(),
+		Free:  free,
+		Busy:  busy,
+	}
+}
+
+// Write a VCALENDAR object with its VEVENT and VFREEBUSY components.
+func Write(w io.Writer, c *Calendar) error {
+	prodID := c.ProdID
+	if prodID == "" {
+		prodID = defaultProdID
+	}
+
+	lines := []string{"BEGIN:VCALENDAR", "VERSION:2.0", "PRODID:" + prodID}
+	for _, event := range c.Events {
+		lines = append(lines, eventLines(event)...)
+	}
+	for _, fb := range c.FreeBusy {
+		lines = append(lines, freeBusyLines(fb)...)
+	}
+	lines = append(lines, "END:VCALENDAR")
+	return writeLines(w, lines)
+}
+
+// Write a VFREEBUSY component. Times are written in UTC.
+func WriteFreeBusy(w io.Writer, fb *FreeBusy) error {
+	return writeLines(w, freeBusyLines(fb))
+}
+
+func writeLines(w io.Writer, lines []string) error {
+	for _, line := range lines {
+		if err := ical.WriteLine(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func stamp(t time.Time) string {
+	if t.IsZero() {
+		t = time.Now()
+	}
+	return "DTSTAMP:" + ical.FormatUTC(t)
+}
+
+func freeBusyLines(fb *FreeBusy) []string {
+	lines := []string{"BEGIN:VFREEBUSY"}
+	if fb.UID != "" {
+		lines = append(lines, "UID:"+ical.Escape(fb.UID))
+	}
+	lines = append(lines, stamp(fb.Stamp))
+	if !fb.Start.IsZero() {
+		lines = append(lines, "DTSTART:"+ical.FormatUTC(fb.Start))
+	}
+	if !fb.End.IsZero() {
+		lines = append(lines, "DTEND:"+ical.FormatUTC(fb.End))
+	}
+	lines = appendPeriods(lines, "FREE", fb.Free)
+	lines = appendPeriods(lines, "BUSY", fb.Busy)
+	lines = appendPeriods(lines, "BUSY-TENTATIVE", fb.Tentative)
+	return append(lines, "END:VFREEBUSY")
+}
+
+func appendPeriods[T timeslots.Period](lines []string, fbType string, periods []T) []string {
+	if len(periods) == 0 {
+		return lines
+	}
+	values := make([]string, len(periods))
+	for i, p := range periods {
+		values[i] = fmt.Sprintf("%s/%s", ical.FormatUTC(p.Start()), ical.FormatUTC(p.End()))
+	}
+	return append(lines, "FREEBUSY;FBTYPE="+fbType+":"+strings.Join(values, ","))
+}
+
+func eventLines(e *Event) []string {
+	lines := []string{"BEGIN:VEVENT"}
+	if e.UID != "" {
+		lines = append(lines, "UID:"+ical.Escape(e.UID))
+	}
+	lines = append(lines, stamp(e.Stamp))
+	if e.AllDay {
+		lines = append(lines,
+			"DTSTART;VALUE=DATE:"+e.Start.Format(ical.DateFormat),
+			"DTEND;VALUE=DATE:"+e.End.Format(ical.DateFormat),
+		)
+	} else {
+		lines = append(lines, dateTime("DTSTART", e.Start), dateTime("DTEND", e.End))
+	}
+	if !e.RecurrenceID.IsZero() {
+		if e.AllDay {
+			lines = append(lines, "RECURRENCE-ID;VALUE=DATE:"+e.RecurrenceID.Format(ical.DateFormat))
+		} else {
+			lines = append(lines, dateTime("RECURRENCE-ID", e.RecurrenceID))
+		}
+	}
+	if e.Summary != "" {
+		lines = append(lines, "SUMMARY:"+ical.Escape(e.Summary))
+	}
+	if e.Transparent {
+		lines = append(lines, "TRANSP:TRANSPARENT")
+	}
+	if e.Cancelled {
+		lines = append(lines, "STATUS:CANCELLED")
+	}
+	if r := e.Recurrence; r != nil {
+		for _, rule := range r.Rules {
+			if e.AllDay {
+				lines = append(lines, "RRULE:"+rule.DateString())
+			} else {
+				lines = append(lines, "RRULE:"+rule.String())
+			}
+		}
+		lines = appendDates(lines, "RDATE", r.RDates, e)
+		lines = appendDates(lines, "EXDATE", r.ExDates, e)
+	}
+	return append(lines, "END:VEVENT")
+}
+
+// Format the time with TZID, so that the recurrence follows the wall clock of the location.
+// It is written in UTC when the location has no IANA name, or the name does not resolve to the same offset. (e.g. time.FixedZone)
+func dateTime(name string, t time.Time) string {
+	if !resolvable(t) {
+		return name + ":" + ical.FormatUTC(t)
+	}
+	return name + ";TZID=" + t.Location().String() + ":" + t.Format(ical.DateTimeFormat)
+}
+
+// Whether the name of the location is loaded back to the same offset at the time.
+func resolvable(t time.Time) bool {
+	loc := t.Location()
+	if loc == time.UTC || loc == time.Local || loc.String() == "" || loc.String() == "Local" {
+		return false
+	}
+	loaded, err := time.LoadLocation(loc.String())
+	if err != nil {
+		return false
+	}
+	_, offset := t.Zone()
+	_, loadedOffset := t.In(loaded).Zone()
+	return offset == loadedOffset
+}
+
+// The dates are written in the same value type as DTSTART of the Event, as RFC 5545 requires.
+func appendDates(lines []string, name string, dates []time.Time, e *Event) []string {
+	if len(dates) == 0 {
+		return lines
+	}
+	values := make([]string, len(dates))
+	switch {
+	case e.AllDay:
+		for i, d := range dates {
+			values[i] = d.In(e.Start.Location()).Format(ical.DateFormat)
+		}
+		name += ";VALUE=DATE"
+	case resolvable(e.Start):
+		for i, d := range dates {
+			values[i] = d.In(e.Start.Location()).Format(ical.DateTimeFormat)
+		}
+		name += ";TZID=" + e.Start.Location().String()
+	default:
+		for i, d := range dates {
+			values[i] = ical.FormatUTC(d)
+		}
+	}
+	return append(lines, name+":"+strings.Join(values, ","))
+}
@@ -0,0 +1,198 @@
+package ics_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"timeslots"
+	"timeslots/ics"
+	"timeslots/recurrence"
+)
+
+func TestWriteFreeBusy(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	at := func(hour int) time.Time {
+		return time.Date(2024, 9, 3, hour, 0, 0, 0, tokyo)
+	}
+
+	span, _ := timeslots.NewSpan(at(9), at(18))
+	blocks := []*timeslots.Block{
+		timeslots.NewBlockWithoutValidating(at(10), at(11)),
+		timeslots.NewBlockWithoutValidating(at(13), at(14)),
+	}
+	fb := ics.NewFreeBusy("room-3", span, timeslots.Find(blocks, span), blocks)
+	fb.Stamp = at(0)
+	fb.Tentative = []*timeslots.Block{timeslots.NewBlockWithoutValidating(at(16), at(17))}
+
+	var buf bytes.Buffer
+	if err := ics.WriteFreeBusy(&buf, fb); err != nil {
+		t.Fatal(err)
+	}
+
+	want := strings.Join([]string{
+		"BEGIN:VFREEBUSY",
+		"UID:room-3",
+		"DTSTAMP:20240902T150000Z",
+		"DTSTART:20240903T000000Z",
+		"DTEND:20240903T090000Z",
+		"FREEBUSY;FBTYPE=FREE:20240903T000000Z/20240903T010000Z,20240903T020000Z/202",
+		" 40903T040000Z,20240903T050000Z/20240903T090000Z",
+		"FREEBUSY;FBTYPE=BUSY:20240903T010000Z/20240903T020000Z,20240903T040000Z/202",
+		" 40903T050000Z",
+		"FREEBUSY;FBTYPE=BUSY-TENTATIVE:20240903T070000Z/20240903T080000Z",
+		"END:VFREEBUSY",
+		"",
+	}, "\r\n")
+	if buf.String() != want {
+		t.Errorf("WriteFreeBusy() =\n%v\nwant\n%v", buf.String(), want)
+	}
+}
+
+func TestWriteAndRead(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	rule, _ := recurrence.ParseRule("FREQ=WEEKLY;BYDAY=TU;COUNT=3", tokyo)
+	start := time.Date(2024, 9, 3, 10, 0, 0, 0, tokyo)
+
+	calendar := &ics.Calendar{
+		Events: []*ics.Event{
+			{
+				UID:     "meeting",
+				Stamp:   start,
+				Summary: "Weekly; meeting, room 3",
+				Start:   start,
+				End:     start.Add(time.Hour),
+				Recurrence: &recurrence.Recurrence{
+					Start:    start,
+					Duration: time.Hour,
+					Rules:    []*recurrence.Rule{rule},
+					ExDates:  []time.Time{start.AddDate(0, 0, 7)},
+				},
+			},
+			{
+				UID:    "holiday",
+				Stamp:  start,
+				Start:  time.Date(2024, 9, 16, 0, 0, 0, 0, tokyo),
+				End:    time.Date(2024, 9, 17, 0, 0, 0, 0, tokyo),
+				AllDay: true,
+			},
+		},
+		FreeBusy: []*ics.FreeBusy{
+			{UID: "fb", Stamp: start},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := ics.Write(&buf, calendar); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ics.Read(&buf, tokyo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Errors) != 0 || len(got.Events) != 2 {
+		t.Fatalf("got %d events and %v errors", len(got.Events), got.Errors)
+	}
+	if got.Events[0].Summary != calendar.Events[0].Summary {
+		t.Errorf("Summary = %v, want %v", got.Events[0].Summary, calendar.Events[0].Summary)
+	}
+
+	span, _ := timeslots.NewSpan(time.Date(2024, 9, 1, 0, 0, 0, 0, tokyo), time.Date(2024, 10, 1, 0, 0, 0, 0, tokyo))
+	want := calendar.Blocks(span)
+	blocks := got.Blocks(span)
+	if timeslots.ToString(blocks) != timeslots.ToString(want) || len(blocks) != 3 {
+		t.Errorf("Blocks() = %v, want %v", timeslots.ToString(blocks), timeslots.ToString(want))
+	}
+}
+
+func TestWriteAndReadFixedZone(t *testing.T) {
+	for _, loc := range []*time.Location{
+		time.FixedZone("", 9*60*60),
+		time.FixedZone("Asia/Tokyo", 9*60*60),
+		time.FixedZone("America/New_York", 9*60*60),
+	} {
+		start := time.Date(2024, 9, 3, 10, 0, 0, 0, loc)
+		calendar := &ics.Calendar{
+			Events: []*ics.Event{
+				{UID: "meeting", Stamp: start, Start: start, End: start.Add(time.Hour)},
+			},
+		}
+
+		var buf bytes.Buffer
+		if err := ics.Write(&buf, calendar); err != nil {
+			t.Fatal(err)
+		}
+		got, err := ics.Read(&buf, time.UTC)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got.Events) != 1 || !got.Events[0].Start.Equal(start) || !got.Events[0].End.Equal(start.Add(time.Hour)) {
+			t.Errorf("%q: got %v, want the event from %v", loc, got.Events, start)
+		}
+	}
+}
+
+func TestWriteRecurrenceValueTypes(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+
+	allDayStart := time.Date(2024, 9, 3, 0, 0, 0, 0, tokyo)
+	allDayRule, _ := recurrence.ParseRule("FREQ=WEEKLY;UNTIL=20240924", tokyo)
+	timedStart := time.Date(2024, 9, 3, 10, 0, 0, 0, tokyo)
+	timedRule, _ := recurrence.ParseRule("FREQ=WEEKLY;COUNT=3", tokyo)
+
+	calendar := &ics.Calendar{
+		Events: []*ics.Event{
+			{
+				UID:    "all-day",
+				Stamp:  allDayStart,
+				Start:  allDayStart,
+				End:    allDayStart.AddDate(0, 0, 1),
+				AllDay: true,
+				Recurrence: &recurrence.Recurrence{
+					Start:    allDayStart,
+					Duration: 24 * time.Hour,
+					Rules:    []*recurrence.Rule{allDayRule},
+					ExDates:  []time.Time{allDayStart.AddDate(0, 0, 7)},
+				},
+			},
+			{
+				UID:   "timed",
+				Stamp: timedStart,
+				Start: timedStart,
+				End:   timedStart.Add(time.Hour),
+				Recurrence: &recurrence.Recurrence{
+					Start:    timedStart,
+					Duration: time.Hour,
+					Rules:    []*recurrence.Rule{timedRule},
+					RDates:   []time.Time{timedStart.AddDate(0, 0, 1)},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := ics.Write(&buf, calendar); err != nil {
+		t.Fatal(err)
+	}
+	text := buf.String()
+	for _, want := range []string{
+		"RRULE:FREQ=WEEKLY;UNTIL=20240924\r\n",
+		"EXDATE;VALUE=DATE:20240910\r\n",
+		"RDATE;TZID=Asia/Tokyo:20240904T100000\r\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Write() does not contain %q:\n%s", want, text)
+		}
+	}
+
+	got, err := ics.Read(&buf, tokyo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	span, _ := timeslots.NewSpan(allDayStart, allDayStart.AddDate(0, 1, 0))
+	if blocks, want := got.Blocks(span), calendar.Blocks(span); timeslots.ToString(blocks) != timeslots.ToString(want) || len(blocks) != 7 {
+		t.Errorf("Blocks() = %v, want %v", timeslots.ToString(blocks), timeslots.ToString(want))
+	}
+}
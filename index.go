@@ -0,0 +1,258 @@
+package timeslots
+
+import (
+	"time"
+)
+
+// This is a node of the interval tree. It is an AVL tree keyed by the period of the Block expanded by its own buffer, augmented with the latest end time in the subtree.
+type node struct {
+	block  *Block
+	key    *Block
+	left   *node
+	right  *node
+	height int
+	maxEnd time.Time
+}
+
+func height(n *node) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func (n *node) update() {
+	n.height = max(height(n.left), height(n.right)) + 1
+	n.maxEnd = n.key.end
+	if n.left != nil && n.left.maxEnd.After(n.maxEnd) {
+		n.maxEnd = n.left.maxEnd
+	}
+	if n.right != nil && n.right.maxEnd.After(n.maxEnd) {
+		n.maxEnd = n.right.maxEnd
+	}
+}
+
+func (n *node) rotateRight() *node {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	n.update()
+	l.update()
+	return l
+}
+
+func (n *node) rotateLeft() *node {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	n.update()
+	r.update()
+	return r
+}
+
+func (n *node) balance() *node {
+	n.update()
+	switch diff := height(n.left) - height(n.right); {
+	case diff > 1:
+		if height(n.left.left) < height(n.left.right) {
+			n.left = n.left.rotateLeft()
+		}
+		return n.rotateRight()
+	case diff < -1:
+		if height(n.right.right) < height(n.right.left) {
+			n.right = n.right.rotateRight()
+		}
+		return n.rotateLeft()
+	}
+	return n
+}
+
+// The order of the nodes. Blocks with the same start time are ordered by the end time.
+func compareBlocks(a, b *Block) int {
+	if c := a.start.Compare(b.start); c != 0 {
+		return c
+	}
+	return a.end.Compare(b.end)
+}
+
+func insert(n *node, block, key *Block) *node {
+	if n == nil {
+		return &node{block: block, key: key, height: 1, maxEnd: key.end}
+	}
+	if compareBlocks(key, n.key) < 0 {
+		n.left = insert(n.left, block, key)
+	} else {
+		n.right = insert(n.right, block, key)
+	}
+	return n.balance()
+}
+
+func deleteMin(n *node) (*node, *node) {
+	if n.left == nil {
+		return n.right, n
+	}
+	var min *node
+	n.left, min = deleteMin(n.left)
+	return n.balance(), min
+}
+
+func remove(n *node, block, key *Block) (*node, bool) {
+	if n == nil {
+		return nil, false
+	}
+
+	var removed bool
+	switch c := compareBlocks(key, n.key); {
+	case c < 0:
+		n.left, removed = remove(n.left, block, key)
+	case c > 0:
+		n.right, removed = remove(n.right, block, key)
+	default:
+		if n.block != block {
+			// Blocks of the same period may be in both subtrees.
+			if n.left, removed = remove(n.left, block, key); !removed {
+				n.right, removed = remove(n.right, block, key)
+			}
+			break
+		}
+		if n.left == nil {
+			return n.right, true
+		}
+		if n.right == nil {
+			return n.left, true
+		}
+		var min *node
+		n.right, min = deleteMin(n.right)
+		min.left, min.right = n.left, n.right
+		return min.balance(), true
+	}
+	if !removed {
+		return n, false
+	}
+	return n.balance(), true
+}
+
+// Visit the blocks overlapping the period from start to end including their own buffers, in order of the expanded start time. Subtrees ending before the start are skipped.
+func (n *node) overlapping(start, end time.Time, yield func(*Block) bool) bool {
+	if n == nil || !n.maxEnd.After(start) {
+		return true
+	}
+	if !n.left.overlapping(start, end, yield) {
+		return false
+	}
+	if !n.key.start.Before(end) {
+		return false
+	}
+	if n.key.end.After(start) && !yield(n.block) {
+		return false
+	}
+	return n.right.overlapping(start, end, yield)
+}
+
+// This is an index of Blocks for schedules updated incrementally. Insert and Delete take O(log n), and the queries take O(log n + k) for k Blocks in the period.
+// The Index is not safe for concurrent use. Use Schedule for that.
+type Index struct {
+	root *node
+	size int
+}
+
+// Creates a new Index with the Blocks.
+func NewIndex(blocks ...*Block) *Index {
+	index := &Index{}
+	for _, block := range blocks {
+		index.Insert(block)
+	}
+	return index
+}
+
+// Add the Block to the Index.
+func (x *Index) Insert(block *Block) {
+	x.root = insert(x.root, block, block.padded(nil))
+	x.size++
+}
+
+// Remove the Block from the Index. The Block is identified by the pointer, not by the period.
+// It reports whether the Block was found.
+func (x *Index) Delete(block *Block) bool {
+	var removed bool
+	x.root, removed = remove(x.root, block, block.padded(nil))
+	if removed {
+		x.size--
+	}
+	return removed
+}
+
+// The number of Blocks in the Index.
+func (x *Index) Len() int {
+	return x.size
+}
+
+// All the Blocks in order of the start time including their own buffers.
+func (x *Index) Blocks() []*Block {
+	blocks := make([]*Block, 0, x.size)
+	var walk func(*node)
+	walk = func(n *node) {
+		if n == nil {
+			return
+		}
+		walk(n.left)
+		blocks = append(blocks, n.block)
+		walk(n.right)
+	}
+	walk(x.root)
+	return blocks
+}
+
+// The Blocks conflicting with the Period, in order of the start time including their own buffers.
+// The same rule as Schedule.Book is used, that is, the buffers of the Blocks (and of the Period, if it is a Block) are considered. Touching at the start or end time is not an overlap.
+func (x *Index) Overlapping(p Period) []*Block {
+	return x.conflicting(asBlock(p))
+}
+
+// Whether the Period is fully free, by the same rule as Overlapping.
+func (x *Index) IsFree(p Period) bool {
+	return len(x.conflicting(asBlock(p))) == 0
+}
+
+// It returns a list of available time slots within the Span. Only the Blocks overlapping the Span with the buffers are scanned.
+func (x *Index) Find(span *Span, opts ...Option[*Slot]) []*Slot {
+	if span == nil || !span.Remain() {
+		return []*Slot{}
+	}
+	options := newOptions(opts)
+
+	// Blocks without their own buffer may reach the Span by the buffer given as an option.
+	start, end := span.start, span.end
+	if options.IsSetBuffer() {
+		start = start.Add(-options.Buffer.After)
+		end = end.Add(options.Buffer.Before)
+	}
+
+	blocks := []*Block{}
+	x.root.overlapping(start, end, func(block *Block) bool {
+		blocks = append(blocks, block)
+		return true
+	})
+	return search(pad(blocks, options.Buffer), span, func(s *Slot) *Slot { return s }, options)
+}
+
+// The Period as a Block. A Block is returned as it is, so its own buffer is kept.
+func asBlock(p Period) *Block {
+	if block, ok := p.(*Block); ok {
+		return block
+	}
+	return NewBlockWithoutValidating(p.Start(), p.End())
+}
+
+// The Blocks conflicting with the Block. They conflict when either one, expanded by its own buffer, overlaps the other.
+func (x *Index) conflicting(block *Block) []*Block {
+	key := block.padded(nil)
+	blocks := []*Block{}
+	x.root.overlapping(key.start, key.end, func(other *Block) bool {
+		if other.padded(nil).Overlaps(block) || key.Overlaps(other) {
+			blocks = append(blocks, other)
+		}
+		return true
+	})
+	return blocks
+}
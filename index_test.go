@@ -0,0 +1,105 @@
+package timeslots_test
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+	"timeslots"
+	"timeslots/internal/slice"
+)
+
+func TestIndex(t *testing.T) {
+	h := NewTestingHelper(now)
+	a, b, c := h.Block(1, 2), h.Block(3, 5), h.Block(4, 6)
+	index := timeslots.NewIndex(c, a, b)
+
+	if index.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", index.Len())
+	}
+	if got := index.Blocks(); timeslots.ToString(got) != timeslots.ToString([]*timeslots.Block{a, b, c}) {
+		t.Errorf("Blocks() = %v", timeslots.ToString(got))
+	}
+	if got := index.Find(h.Span(0, 8)); !slice.Equal(got, []*timeslots.Slot{h.Slot(0, 1), h.Slot(2, 3), h.Slot(6, 8)}) {
+		t.Errorf("Find() = %v", slice.String(got))
+	}
+	if got := index.Overlapping(h.Slot(2, 4)); len(got) != 1 || got[0] != b {
+		t.Errorf("Overlapping() = %v", timeslots.ToString(got))
+	}
+	if !index.IsFree(h.Slot(2, 3)) || index.IsFree(h.Slot(2, 4)) {
+		t.Errorf("IsFree() is wrong")
+	}
+
+	same := h.Block(3, 5)
+	if index.Delete(same) {
+		t.Errorf("Delete() removed a different Block of the same period")
+	}
+	index.Insert(same)
+	if !index.Delete(b) || index.Len() != 3 {
+		t.Errorf("Delete() failed")
+	}
+	if got := index.Blocks(); got[1] != same {
+		t.Errorf("Delete() removed a wrong Block")
+	}
+	if index.Delete(b) {
+		t.Errorf("Delete() removed a Block twice")
+	}
+}
+
+func TestIndexWithBuffer(t *testing.T) {
+	h := NewTestingHelper(now)
+	index := timeslots.NewIndex(h.Block(9, 10), h.Block(-3, -2).WithBuffer(0, 3*time.Hour), h.Block(-2, -1))
+
+	got := index.Find(h.Span(0, 8), timeslots.WithBuffer[*timeslots.Slot](time.Hour, time.Hour))
+	want := []*timeslots.Slot{h.Slot(1, 8)}
+	if !slice.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", slice.String(got), slice.String(want))
+	}
+}
+
+func TestIndexMatchesFind(t *testing.T) {
+	h := NewTestingHelper(now)
+	r := rand.New(rand.NewSource(1))
+
+	index := timeslots.NewIndex()
+	blocks := []*timeslots.Block{}
+	for i := 0; i < 2000; i++ {
+		if len(blocks) > 0 && r.Intn(3) == 0 {
+			j := r.Intn(len(blocks))
+			if !index.Delete(blocks[j]) {
+				t.Fatalf("Delete() failed")
+			}
+			blocks = append(blocks[:j], blocks[j+1:]...)
+		} else {
+			start := r.Intn(1000)
+			block := h.Block(start, start+1+r.Intn(5))
+			index.Insert(block)
+			blocks = append(blocks, block)
+		}
+
+		if i%100 != 0 {
+			continue
+		}
+		start := r.Intn(1000)
+		span := h.Span(start, start+r.Intn(100))
+		if got, want := index.Find(span), timeslots.Find(blocks, span); !slice.Equal(got, want) {
+			t.Fatalf("Find() got: %v, want: %v", slice.String(got), slice.String(want))
+		}
+		if got, want := index.Overlapping(span), timeslots.Conflicts(blocks, span); len(got) != len(want) {
+			t.Fatalf("Overlapping() got %d blocks, want %d", len(got), len(want))
+		}
+	}
+	if index.Len() != len(blocks) {
+		t.Errorf("Len() = %d, want %d", index.Len(), len(blocks))
+	}
+}
+
+func BenchmarkIndexFind(b *testing.B) {
+	h := NewTestingHelper(now)
+	index := timeslots.NewIndex(h.HugeBlocks(0, 100000)...)
+	span := h.Span(50000, 50010)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		index.Find(span)
+	}
+}
@@ -0,0 +1,235 @@
+// Package ical provides the pieces of RFC 5545 shared by the recurrence and ics packages.
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DateFormat        = "20060102"
+	DateTimeFormat    = "20060102T150405"
+	UTCDateTimeFormat = "20060102T150405Z"
+)
+
+// A content line such as `DTSTART;TZID=Asia/Tokyo:20240926T100000`.
+type Property struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// Parse a content line. The name and the parameter names are upper-cased.
+func ParseProperty(line string) (*Property, error) {
+	colon := -1
+	quoted := false
+	for i, r := range line {
+		if r == '"' {
+			quoted = !quoted
+		}
+		if r == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return nil, fmt.Errorf("invalid content line: %q", line)
+	}
+
+	head, value := line[:colon], line[colon+1:]
+	parts := strings.Split(head, ";")
+	property := &Property{
+		Name:   strings.ToUpper(strings.TrimSpace(parts[0])),
+		Params: map[string]string{},
+		Value:  value,
+	}
+	if property.Name == "" {
+		return nil, fmt.Errorf("invalid content line: %q", line)
+	}
+	for _, param := range parts[1:] {
+		key, val, ok := strings.Cut(param, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid parameter: %q", param)
+		}
+		property.Params[strings.ToUpper(key)] = strings.Trim(val, `"`)
+	}
+	return property, nil
+}
+
+// Represents the Property as a content line.
+func (p *Property) String() string {
+	keys := make([]string, 0, len(p.Params))
+	for key := range p.Params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var builder strings.Builder
+	builder.WriteString(p.Name)
+	for _, key := range keys {
+		value := p.Params[key]
+		if strings.ContainsAny(value, ":;,") {
+			value = `"` + value + `"`
+		}
+		builder.WriteString(";" + key + "=" + value)
+	}
+	builder.WriteString(":" + p.Value)
+	return builder.String()
+}
+
+// Read content lines, joining folded lines.
+func ReadLines(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	lines := []string{}
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// Resolve the location of a property from its TZID parameter. The fallback is used when there is no TZID.
+func Location(params map[string]string, fallback *time.Location) (*time.Location, error) {
+	tzid, ok := params["TZID"]
+	if !ok {
+		return fallback, nil
+	}
+	return time.LoadLocation(strings.TrimPrefix(tzid, "/"))
+}
+
+// Parse a DATE or DATE-TIME value. A value with the `Z` suffix is in UTC, and the others are in the given location.
+// It also reports whether the value is a DATE.
+func ParseTime(value string, loc *time.Location) (time.Time, bool, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	switch {
+	case len(value) == len(DateFormat):
+		t, err := time.ParseInLocation(DateFormat, value, loc)
+		return t, true, err
+	case strings.HasSuffix(value, "Z"):
+		t, err := time.Parse(UTCDateTimeFormat, value)
+		return t, false, err
+	default:
+		t, err := time.ParseInLocation(DateTimeFormat, value, loc)
+		return t, false, err
+	}
+}
+
+// Parse a comma separated list of DATE or DATE-TIME values.
+func ParseTimes(value string, loc *time.Location) ([]time.Time, error) {
+	times := []time.Time{}
+	for _, v := range strings.Split(value, ",") {
+		t, _, err := ParseTime(strings.TrimSpace(v), loc)
+		if err != nil {
+			return nil, err
+		}
+		times = append(times, t)
+	}
+	return times, nil
+}
+
+// Parse a DURATION value such as `PT1H30M` or `-P1W`.
+func ParseDuration(value string) (time.Duration, error) {
+	s := value
+	sign := time.Duration(1)
+	switch {
+	case strings.HasPrefix(s, "-"):
+		sign = -1
+		s = s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+	if !strings.HasPrefix(s, "P") || len(s) < 3 {
+		return 0, fmt.Errorf("invalid duration: %q", value)
+	}
+	s = s[1:]
+
+	var d time.Duration
+	inTime := false
+	number := ""
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			number += string(r)
+			continue
+		case r == 'T' && !inTime && number == "":
+			inTime = true
+			continue
+		}
+
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration: %q", value)
+		}
+		number = ""
+		switch {
+		case r == 'W' && !inTime:
+			d += time.Duration(n) * 7 * 24 * time.Hour
+		case r == 'D' && !inTime:
+			d += time.Duration(n) * 24 * time.Hour
+		case r == 'H' && inTime:
+			d += time.Duration(n) * time.Hour
+		case r == 'M' && inTime:
+			d += time.Duration(n) * time.Minute
+		case r == 'S' && inTime:
+			d += time.Duration(n) * time.Second
+		default:
+			return 0, fmt.Errorf("invalid duration: %q", value)
+		}
+	}
+	if number != "" {
+		return 0, fmt.Errorf("invalid duration: %q", value)
+	}
+	return sign * d, nil
+}
+
+// Format the time as a DATE-TIME value in UTC.
+func FormatUTC(t time.Time) string {
+	return t.UTC().Format(UTCDateTimeFormat)
+}
+
+// Write a content line folded at 75 octets, terminated by CRLF.
+func WriteLine(w io.Writer, line string) error {
+	var builder strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > 75 {
+			builder.WriteString("\r\n ")
+			width = 1
+		}
+		builder.WriteRune(r)
+		width += size
+	}
+	builder.WriteString("\r\n")
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+// Escape a TEXT value.
+func Escape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(value)
+}
+
+// Unescape a TEXT value.
+func Unescape(value string) string {
+	return strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(value)
+}
@@ -0,0 +1,159 @@
+package ical_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"timeslots/internal/ical"
+)
+
+func TestParseProperty(t *testing.T) {
+	tests := []struct {
+		name       string
+		line       string
+		wantName   string
+		wantParams map[string]string
+		wantValue  string
+		wantErr    bool
+	}{
+		{
+			name:       "Without parameters",
+			line:       "DTSTART:20240903T100000Z",
+			wantName:   "DTSTART",
+			wantParams: map[string]string{},
+			wantValue:  "20240903T100000Z",
+		},
+		{
+			name:       "With parameters",
+			line:       "dtstart;tzid=Asia/Tokyo;VALUE=DATE-TIME:20240903T100000",
+			wantName:   "DTSTART",
+			wantParams: map[string]string{"TZID": "Asia/Tokyo", "VALUE": "DATE-TIME"},
+			wantValue:  "20240903T100000",
+		},
+		{
+			name:       "Quoted parameter with colon",
+			line:       `ATTENDEE;CN="Doe: John":mailto:john@example.com`,
+			wantName:   "ATTENDEE",
+			wantParams: map[string]string{"CN": "Doe: John"},
+			wantValue:  "mailto:john@example.com",
+		},
+		{
+			name:    "Without value",
+			line:    "DTSTART",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ical.ParseProperty(tt.line)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseProperty() error = %v, wantErr = %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Name != tt.wantName || got.Value != tt.wantValue {
+				t.Errorf("ParseProperty() = %v:%v, want %v:%v", got.Name, got.Value, tt.wantName, tt.wantValue)
+			}
+			for k, v := range tt.wantParams {
+				if got.Params[k] != v {
+					t.Errorf("param %v = %v, want %v", k, got.Params[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestReadLines(t *testing.T) {
+	got, err := ical.ReadLines(strings.NewReader("BEGIN:VEVENT\r\nSUMMARY:Long\r\n  title\r\n\r\nEND:VEVENT\r\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"BEGIN:VEVENT", "SUMMARY:Long title", "END:VEVENT"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("ReadLines() = %v, want %v", got, want)
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+
+	tests := []struct {
+		name       string
+		value      string
+		want       time.Time
+		wantAllDay bool
+		wantErr    bool
+	}{
+		{name: "Date", value: "20240903", want: time.Date(2024, 9, 3, 0, 0, 0, 0, tokyo), wantAllDay: true},
+		{name: "Local", value: "20240903T100000", want: time.Date(2024, 9, 3, 10, 0, 0, 0, tokyo)},
+		{name: "UTC", value: "20240903T100000Z", want: time.Date(2024, 9, 3, 10, 0, 0, 0, time.UTC)},
+		{name: "Broken", value: "2024-09-03", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, allDay, err := ical.ParseTime(tt.value, tokyo)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTime() error = %v, wantErr = %v", err, tt.wantErr)
+			}
+			if err == nil && (!got.Equal(tt.want) || allDay != tt.wantAllDay) {
+				t.Errorf("ParseTime() = %v, %v, want %v, %v", got, allDay, tt.want, tt.wantAllDay)
+			}
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "PT1H30M", want: 90 * time.Minute},
+		{value: "P1W", want: 7 * 24 * time.Hour},
+		{value: "-P1DT12H", want: -36 * time.Hour},
+		{value: "+PT15S", want: 15 * time.Second},
+		{value: "P", wantErr: true},
+		{value: "PT1D", wantErr: true},
+		{value: "P1H", wantErr: true},
+		{value: "PT1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ical.ParseDuration(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDuration() error = %v, wantErr = %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteLine(t *testing.T) {
+	var builder strings.Builder
+	if err := ical.WriteLine(&builder, "SUMMARY:"+strings.Repeat("a", 80)); err != nil {
+		t.Fatal(err)
+	}
+	want := "SUMMARY:" + strings.Repeat("a", 67) + "\r\n " + strings.Repeat("a", 13) + "\r\n"
+	if builder.String() != want {
+		t.Errorf("WriteLine() = %q, want %q", builder.String(), want)
+	}
+
+	lines, err := ical.ReadLines(strings.NewReader(builder.String()))
+	if err != nil || len(lines) != 1 || lines[0] != "SUMMARY:"+strings.Repeat("a", 80) {
+		t.Errorf("ReadLines() = %v, %v", lines, err)
+	}
+}
+
+func TestEscape(t *testing.T) {
+	value := "a, b; c\\d\ne"
+	if got := ical.Unescape(ical.Escape(value)); got != value {
+		t.Errorf("Unescape(Escape()) = %q, want %q", got, value)
+	}
+}
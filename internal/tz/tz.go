@@ -0,0 +1,27 @@
+package tz
+
+import "time"
+
+// Date returns the time of the wall clock in the location like time.Date, but resolves the DST transitions deterministically.
+// A wall clock skipped by a gap is shifted forward by the length of the gap, and a wall clock repeated by an overlap is the first occurrence.
+func Date(year int, month time.Month, day, hour, minute, second int, loc *time.Location) time.Time {
+	wall := time.Date(year, month, day, hour, minute, second, 0, time.UTC)
+	_, before := wall.Add(-24 * time.Hour).In(loc).Zone()
+	_, after := wall.Add(24 * time.Hour).In(loc).Zone()
+
+	// The larger offset gives the earlier instant, which is the first occurrence in an overlap.
+	for _, offset := range []int{max(before, after), min(before, after)} {
+		if t := wall.Add(-time.Duration(offset) * time.Second).In(loc); sameWall(t, wall) {
+			return t
+		}
+	}
+	// The wall clock does not exist, so it is read with the offset before the gap.
+	return wall.Add(-time.Duration(before) * time.Second).In(loc)
+}
+
+func sameWall(t, wall time.Time) bool {
+	year, month, day := t.Date()
+	hour, minute, second := t.Clock()
+	return year == wall.Year() && month == wall.Month() && day == wall.Day() &&
+		hour == wall.Hour() && minute == wall.Minute() && second == wall.Second()
+}
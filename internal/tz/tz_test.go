@@ -0,0 +1,53 @@
+package tz
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDate(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	tests := []struct {
+		name string
+		got  time.Time
+		want string
+	}{
+		{
+			name: "Standard time",
+			got:  Date(2024, time.January, 10, 9, 0, 0, newYork),
+			want: "2024-01-10T09:00:00-05:00",
+		},
+		{
+			name: "Daylight saving time",
+			got:  Date(2024, time.July, 10, 9, 0, 0, newYork),
+			want: "2024-07-10T09:00:00-04:00",
+		},
+		{
+			name: "Gap is shifted forward",
+			got:  Date(2024, time.March, 10, 2, 30, 0, newYork),
+			want: "2024-03-10T03:30:00-04:00",
+		},
+		{
+			name: "Overlap is the first occurrence",
+			got:  Date(2024, time.November, 3, 1, 30, 0, newYork),
+			want: "2024-11-03T01:30:00-04:00",
+		},
+		{
+			name: "Normalized like time.Date",
+			got:  Date(2024, time.January, 31, 24, 0, 0, time.UTC),
+			want: "2024-02-01T00:00:00Z",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.Format(time.RFC3339); got != tt.want {
+				t.Errorf("Date() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package timeslots
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The layouts of the time accepted in an ISO 8601 interval. A time without the offset is in the given location.
+var isoLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04",
+	"20060102T150405Z07:00",
+	"20060102T150405Z",
+	"20060102T150405",
+}
+
+// The duration of ISO 8601. The years, months and days are added on the calendar.
+type isoDuration struct {
+	negative bool
+	years    int
+	months   int
+	days     int
+	clock    time.Duration
+}
+
+func parseISODuration(s string) (isoDuration, error) {
+	d := isoDuration{}
+	value := s
+	switch {
+	case strings.HasPrefix(value, "-"):
+		d.negative = true
+		value = value[1:]
+	case strings.HasPrefix(value, "+"):
+		value = value[1:]
+	}
+	if !strings.HasPrefix(value, "P") || len(value) < 3 {
+		return d, fmt.Errorf("invalid duration: %q", s)
+	}
+	value = value[1:]
+
+	inTime := false
+	number := ""
+	for _, r := range value {
+		switch {
+		case (r >= '0' && r <= '9') || r == '.' || r == ',':
+			number += string(r)
+			continue
+		case r == 'T' && !inTime && number == "":
+			inTime = true
+			continue
+		}
+
+		if number == "" {
+			return d, fmt.Errorf("invalid duration: %q", s)
+		}
+		if !inTime || r != 'S' {
+			n, err := strconv.Atoi(number)
+			if err != nil {
+				return d, fmt.Errorf("invalid duration: %q", s)
+			}
+			switch {
+			case r == 'Y' && !inTime:
+				d.years += n
+			case r == 'M' && !inTime:
+				d.months += n
+			case r == 'W' && !inTime:
+				d.days += 7 * n
+			case r == 'D' && !inTime:
+				d.days += n
+			case r == 'H' && inTime:
+				d.clock += time.Duration(n) * time.Hour
+			case r == 'M' && inTime:
+				d.clock += time.Duration(n) * time.Minute
+			default:
+				return d, fmt.Errorf("invalid duration: %q", s)
+			}
+			number = ""
+			continue
+		}
+
+		seconds, err := strconv.ParseFloat(strings.Replace(number, ",", ".", 1), 64)
+		if err != nil {
+			return d, fmt.Errorf("invalid duration: %q", s)
+		}
+		d.clock += time.Duration(seconds * float64(time.Second))
+		number = ""
+	}
+	if number != "" || (inTime && strings.HasSuffix(value, "T")) {
+		return d, fmt.Errorf("invalid duration: %q", s)
+	}
+	return d, nil
+}
+
+// Add the duration to the time, or subtract it when the sign is negative.
+func (d isoDuration) addTo(t time.Time, sign int) time.Time {
+	if d.negative {
+		sign = -sign
+	}
+	return t.AddDate(sign*d.years, sign*d.months, sign*d.days).Add(time.Duration(sign) * d.clock)
+}
+
+// Parse an ISO 8601 duration such as "PT1H30M", "P1DT12H" or "P2W". A day is treated as 24 hours.
+// Years and months are rejected because their length depends on the calendar.
+func ParseDuration(s string) (time.Duration, error) {
+	d, err := parseISODuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d.years != 0 || d.months != 0 {
+		return 0, fmt.Errorf("years and months are not supported: %q", s)
+	}
+	duration := time.Duration(d.days)*24*time.Hour + d.clock
+	if d.negative {
+		return -duration, nil
+	}
+	return duration, nil
+}
+
+// Represents the duration in ISO 8601. (e.g. "P1DT2H30M")
+func FormatDuration(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+
+	var builder strings.Builder
+	if d < 0 {
+		builder.WriteString("-")
+		d = -d
+	}
+	builder.WriteString("P")
+	if days := d / (24 * time.Hour); days > 0 {
+		builder.WriteString(strconv.FormatInt(int64(days), 10) + "D")
+		d -= days * 24 * time.Hour
+	}
+	if d == 0 {
+		return builder.String()
+	}
+	builder.WriteString("T")
+	if hours := d / time.Hour; hours > 0 {
+		builder.WriteString(strconv.FormatInt(int64(hours), 10) + "H")
+		d -= hours * time.Hour
+	}
+	if minutes := d / time.Minute; minutes > 0 {
+		builder.WriteString(strconv.FormatInt(int64(minutes), 10) + "M")
+		d -= minutes * time.Minute
+	}
+	if d > 0 {
+		builder.WriteString(strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "S")
+	}
+	return builder.String()
+}
+
+func parseISOTime(s string, loc *time.Location) (time.Time, error) {
+	for _, layout := range isoLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time: %q", s)
+}
+
+// Parse an ISO 8601 interval in the form of "start/end", "start/duration" or "duration/end".
+// A time without the offset is in the given location.
+func ParseInterval(s string, loc *time.Location) (time.Time, time.Time, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+
+	first, second, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid interval: %q", s)
+	}
+
+	switch {
+	case isDuration(first) && isDuration(second):
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid interval: %q", s)
+	case isDuration(first):
+		d, err := parseISODuration(first)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		end, err := parseISOTime(second, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		return d.addTo(end, -1), end, nil
+	case isDuration(second):
+		start, err := parseISOTime(first, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		d, err := parseISODuration(second)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		return start, d.addTo(start, 1), nil
+	}
+
+	start, err := parseISOTime(first, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	end, err := parseISOTime(second, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return start, end, nil
+}
+
+func isDuration(s string) bool {
+	return strings.HasPrefix(strings.TrimLeft(s, "+-"), "P")
+}
+
+// Represents the period as an ISO 8601 interval in the form of "start/end". (e.g. "2024-09-26T19:42:44+09:00/2024-09-27T03:42:44+09:00")
+func FormatInterval[T Period](p T) string {
+	return p.Start().Format(time.RFC3339Nano) + "/" + p.End().Format(time.RFC3339Nano)
+}
+
+// Read the periods back from the string made by ToString. Each line may also be an ISO 8601 interval.
+// Times without the offset are in the given location, and each period is created by newFunc such as NewSlot, NewBlock or NewSpan.
+func FromString[T Period](s string, loc *time.Location, newFunc func(start, end time.Time) (T, error)) ([]T, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+
+	periods := []T{}
+	scanner := bufio.NewScanner(strings.NewReader(s))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		start, end, err := parseLine(line, loc)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		p, err := newFunc(start, end)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		periods = append(periods, p)
+	}
+	return periods, scanner.Err()
+}
+
+func parseLine(line string, loc *time.Location) (time.Time, time.Time, error) {
+	first, second, ok := strings.Cut(line, ", ")
+	if !ok {
+		return ParseInterval(line, loc)
+	}
+	start, err := time.ParseInLocation(TimeFormat, first, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	end, err := time.ParseInLocation(TimeFormat, second, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return start, end, nil
+}
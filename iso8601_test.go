@@ -0,0 +1,159 @@
+package timeslots_test
+
+import (
+	"testing"
+	"time"
+	"timeslots"
+	"timeslots/internal/slice"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{input: "PT1H30M", want: 90 * time.Minute},
+		{input: "P1DT12H", want: 36 * time.Hour},
+		{input: "P2W", want: 14 * 24 * time.Hour},
+		{input: "PT0.5S", want: 500 * time.Millisecond},
+		{input: "-PT15M", want: -15 * time.Minute},
+		{input: "P1M", wantErr: true},
+		{input: "P1Y", wantErr: true},
+		{input: "PT", wantErr: true},
+		{input: "P1DT", wantErr: true},
+		{input: "1H", wantErr: true},
+		{input: "PT1.5H", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := timeslots.ParseDuration(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDuration() error = %v, wantErr = %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		input time.Duration
+		want  string
+	}{
+		{input: 0, want: "PT0S"},
+		{input: 90 * time.Minute, want: "PT1H30M"},
+		{input: 36 * time.Hour, want: "P1DT12H"},
+		{input: 48 * time.Hour, want: "P2D"},
+		{input: -1500 * time.Millisecond, want: "-PT1.5S"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got := timeslots.FormatDuration(tt.input)
+			if got != tt.want {
+				t.Errorf("FormatDuration() = %v, want %v", got, tt.want)
+			}
+			back, err := timeslots.ParseDuration(got)
+			if err != nil || back != tt.input {
+				t.Errorf("ParseDuration(FormatDuration()) = %v, %v, want %v", back, err, tt.input)
+			}
+		})
+	}
+}
+
+func TestParseInterval(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	at := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2024, month, day, hour, 0, 0, 0, tokyo)
+	}
+
+	tests := []struct {
+		name      string
+		input     string
+		wantStart time.Time
+		wantEnd   time.Time
+		wantErr   bool
+	}{
+		{
+			name:      "Start and end",
+			input:     "2024-09-26T10:00:00+09:00/2024-09-26T03:00:00Z",
+			wantStart: at(time.September, 26, 10),
+			wantEnd:   at(time.September, 26, 12),
+		},
+		{
+			name:      "Start and duration",
+			input:     "2024-09-26T10:00:00/PT2H",
+			wantStart: at(time.September, 26, 10),
+			wantEnd:   at(time.September, 26, 12),
+		},
+		{
+			name:      "Duration and end",
+			input:     "P1M/2024-10-26T10:00",
+			wantStart: at(time.September, 26, 10),
+			wantEnd:   at(time.October, 26, 10),
+		},
+		{
+			name:      "Basic format",
+			input:     "20240926T010000Z/20240926T030000Z",
+			wantStart: at(time.September, 26, 10),
+			wantEnd:   at(time.September, 26, 12),
+		},
+		{
+			name:    "Two durations",
+			input:   "PT1H/PT2H",
+			wantErr: true,
+		},
+		{
+			name:    "Without separator",
+			input:   "2024-09-26T10:00:00Z",
+			wantErr: true,
+		},
+		{
+			name:    "Broken time",
+			input:   "2024-09-26 10:00/PT1H",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := timeslots.ParseInterval(tt.input, tokyo)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseInterval() error = %v, wantErr = %v", err, tt.wantErr)
+			}
+			if err == nil && (!start.Equal(tt.wantStart) || !end.Equal(tt.wantEnd)) {
+				t.Errorf("ParseInterval() = %v, %v, want %v, %v", start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestFromString(t *testing.T) {
+	h := NewTestingHelper(time.Date(2024, 9, 26, 9, 0, 0, 0, time.Local))
+	slots := []*timeslots.Slot{h.Slot(0, 1), h.Slot(2, 8)}
+
+	got, err := timeslots.FromString(timeslots.ToString(slots), time.Local, timeslots.NewSlot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slice.Equal(got, slots) {
+		t.Errorf("got: %v, want: %v", slice.String(got), slice.String(slots))
+	}
+
+	iso := timeslots.FormatInterval(h.Block(0, 1)) + "\n" + timeslots.FormatInterval(h.Block(2, 3))
+	blocks, err := timeslots.FromString(iso, time.Local, timeslots.NewBlock)
+	if err != nil || len(blocks) != 2 || !blocks[1].Start().Equal(h.Block(2, 3).Start()) {
+		t.Errorf("FromString() = %v, %v", timeslots.ToString(blocks), err)
+	}
+
+	if _, err := timeslots.FromString("2024-09-26 10:00:00, 2024-09-26 09:00:00\n", time.Local, timeslots.NewSpan); err == nil {
+		t.Errorf("FromString() error = nil, want error for start after end")
+	}
+	if _, err := timeslots.FromString("broken\n", time.Local, timeslots.NewSpan); err == nil {
+		t.Errorf("FromString() error = nil, want error for broken line")
+	}
+}
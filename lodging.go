@@ -0,0 +1,150 @@
+package timeslots
+
+import (
+	"fmt"
+	"time"
+)
+
+// This is a civil date without the time of day and the location. (e.g. the day of check-in)
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// Creates a new Date. An out-of-range day or month is normalized like time.Date. (e.g. September 31 is October 1)
+func NewDate(year int, month time.Month, day int) Date {
+	return DateOf(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// The date of the time on its own wall clock. Convert the time with In to get the date in another location.
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// Parse the date formatted as "2006-01-02".
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return Date{}, fmt.Errorf("invalid date format: %q", s)
+	}
+	return DateOf(t), nil
+}
+
+// Represents the date as "2006-01-02".
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// The date n days later. A negative n goes back.
+func (d Date) AddDays(n int) Date {
+	return NewDate(d.Year, d.Month, d.Day+n)
+}
+
+// Whether it is earlier than the other Date.
+func (d Date) Before(other Date) bool {
+	return d.utc().Before(other.utc())
+}
+
+// The number of days from the other Date to this one.
+func (d Date) Sub(other Date) int {
+	return int(d.utc().Sub(other.utc()) / (24 * time.Hour))
+}
+
+func (d Date) utc() time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC)
+}
+
+// This is a stay from the date of check-in to the date of check-out.
+type Stay struct {
+	CheckIn  Date
+	CheckOut Date
+}
+
+// The number of nights of the Stay.
+func (s Stay) Nights() int {
+	return s.CheckOut.Sub(s.CheckIn)
+}
+
+// Represents the check-in and check-out dates as strings.
+func (s Stay) String() string {
+	return fmt.Sprintf("%s, %s", s.CheckIn, s.CheckOut)
+}
+
+// This is a resource booked by nights. (e.g. a hotel room) Each night runs from the check-in time to the check-out time on the next day.
+type Lodging struct {
+	checkIn  Clock
+	checkOut Clock
+	location *time.Location
+}
+
+// Creates a new Lodging with the check-in and check-out times. They are interpreted on the wall clock of the location.
+func NewLodging(checkIn, checkOut Clock, loc *time.Location) *Lodging {
+	if loc == nil {
+		loc = time.Local
+	}
+	return &Lodging{
+		checkIn:  checkIn,
+		checkOut: checkOut,
+		location: loc,
+	}
+}
+
+// The location of the Lodging.
+func (l *Lodging) Location() *time.Location {
+	return l.location
+}
+
+// The Block occupied by the Stay, from the check-in time on the first day to the check-out time on the last day.
+func (l *Lodging) Block(stay Stay) *Block {
+	return NewBlockWithoutValidating(l.checkInAt(stay.CheckIn), l.checkOutAt(stay.CheckOut))
+}
+
+// The Stay of the period. The dates are those of the start time and end time in the location.
+func (l *Lodging) Stay(p Period) Stay {
+	return Stay{
+		CheckIn:  DateOf(p.Start().In(l.location)),
+		CheckOut: DateOf(p.End().In(l.location)),
+	}
+}
+
+// The Span from the check-in time on the date `from` to the check-out time on the date `to`.
+func (l *Lodging) Span(from, to Date) *Span {
+	return newSpan(l.checkInAt(from), l.checkOutAt(to))
+}
+
+// It returns the longest available Stays between the dates `from` and `to` (the last date of check-out), which have at least `minNights` nights.
+// Any Stay within a returned one is also available. The options are applied to the free time in the same way as Find.
+func (l *Lodging) FindStays(blocks []*Block, from, to Date, minNights int, opts ...Option[*Slot]) []Stay {
+	stays := []Stay{}
+	if !from.Before(to) {
+		return stays
+	}
+	minNights = max(minNights, 1)
+
+	for _, slot := range Find(blocks, l.Span(from, to), opts...) {
+		checkIn := DateOf(slot.start.In(l.location))
+		if l.checkInAt(checkIn).Before(slot.start) {
+			checkIn = checkIn.AddDays(1)
+		}
+		checkOut := DateOf(slot.end.In(l.location))
+		if l.checkOutAt(checkOut).After(slot.end) {
+			checkOut = checkOut.AddDays(-1)
+		}
+
+		stay := Stay{CheckIn: checkIn, CheckOut: checkOut}
+		if stay.Nights() >= minNights {
+			stays = append(stays, stay)
+		}
+	}
+	return stays
+}
+
+func (l *Lodging) checkInAt(date Date) time.Time {
+	return l.checkIn.on(date.Year, date.Month, date.Day, l.location)
+}
+
+func (l *Lodging) checkOutAt(date Date) time.Time {
+	return l.checkOut.on(date.Year, date.Month, date.Day, l.location)
+}
@@ -0,0 +1,117 @@
+package timeslots_test
+
+import (
+	"testing"
+	"time"
+	"timeslots"
+)
+
+func TestDate(t *testing.T) {
+	d, err := timeslots.ParseDate("2024-09-30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := d.AddDays(1).String(); got != "2024-10-01" {
+		t.Errorf("AddDays(1) = %v, want 2024-10-01", got)
+	}
+	if got := timeslots.NewDate(2024, time.September, 31); got != timeslots.NewDate(2024, time.October, 1) {
+		t.Errorf("NewDate() = %v, want 2024-10-01", got)
+	}
+	if got := timeslots.NewDate(2025, time.March, 1).Sub(timeslots.NewDate(2025, time.February, 1)); got != 28 {
+		t.Errorf("Sub() = %v, want 28", got)
+	}
+	if _, err := timeslots.ParseDate("2024-09-31"); err == nil {
+		t.Error("ParseDate() error = nil, want error")
+	}
+}
+
+func TestLodgingFindStays(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	hotel := timeslots.NewLodging(clock("15:00"), clock("11:00"), newYork)
+	date := func(day int) timeslots.Date {
+		return timeslots.NewDate(2024, time.November, day)
+	}
+	stay := func(checkIn, checkOut int) timeslots.Stay {
+		return timeslots.Stay{CheckIn: date(checkIn), CheckOut: date(checkOut)}
+	}
+
+	blocks := []*timeslots.Block{
+		hotel.Block(stay(3, 5)),
+		// Day use on the 7th
+		timeslots.NewBlockWithoutValidating(
+			time.Date(2024, time.November, 7, 12, 0, 0, 0, newYork),
+			time.Date(2024, time.November, 7, 14, 0, 0, 0, newYork),
+		),
+	}
+
+	tests := []struct {
+		name      string
+		from      timeslots.Date
+		to        timeslots.Date
+		minNights int
+		opts      []timeslots.Option[*timeslots.Slot]
+		want      []timeslots.Stay
+	}{
+		{
+			name:      "Empty range",
+			from:      date(5),
+			to:        date(5),
+			minNights: 1,
+			want:      []timeslots.Stay{},
+		},
+		{
+			name:      "Check-out and check-in on the same day",
+			from:      date(1),
+			to:        date(10),
+			minNights: 1,
+			want:      []timeslots.Stay{stay(1, 3), stay(5, 7), stay(7, 10)},
+		},
+		{
+			name:      "Minimum stay",
+			from:      date(1),
+			to:        date(10),
+			minNights: 3,
+			want:      []timeslots.Stay{stay(7, 10)},
+		},
+		{
+			name:      "Cleaning time after every stay",
+			from:      date(1),
+			to:        date(10),
+			minNights: 1,
+			opts:      []timeslots.Option[*timeslots.Slot]{timeslots.WithBuffer[*timeslots.Slot](0, 5*time.Hour)},
+			want:      []timeslots.Stay{stay(1, 3), stay(6, 7), stay(8, 10)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := hotel.FindStays(blocks, tt.from, tt.to, tt.minNights, tt.opts...)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got: %v, want: %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("got: %v, want: %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestLodgingBlock(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	hotel := timeslots.NewLodging(clock("15:00"), clock("11:00"), newYork)
+	stay := timeslots.Stay{CheckIn: timeslots.NewDate(2024, time.November, 2), CheckOut: timeslots.NewDate(2024, time.November, 4)}
+
+	block := hotel.Block(stay)
+	if got := block.String(); got != "2024-11-02 15:00:00, 2024-11-04 11:00:00" {
+		t.Errorf("Block() = %v", got)
+	}
+	// The night of the fall back is an hour longer.
+	if got := block.End().Sub(block.Start()); got != 45*time.Hour {
+		t.Errorf("Block() lasts %v, want %v", got, 45*time.Hour)
+	}
+	if got := hotel.Stay(block); got != stay || got.Nights() != 2 {
+		t.Errorf("Stay() = %v (%d nights), want %v", got, got.Nights(), stay)
+	}
+}
@@ -0,0 +1,176 @@
+package timeslots
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// The JSON representation of a Period.
+type periodJSON struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// The JSON representation of a Block. The buffer and units are omitted unless they are set to the Block.
+type blockJSON struct {
+	periodJSON
+	Buffer *bufferJSON `json:"buffer,omitempty"`
+	Units  *int        `json:"units,omitempty"`
+}
+
+// The JSON representation of a Buffer in ISO 8601 durations.
+type bufferJSON struct {
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+func marshalJSON[T Period](p T) ([]byte, error) {
+	return json.Marshal(periodJSON{Start: p.Start(), End: p.End()})
+}
+
+func unmarshalJSON(data []byte) (time.Time, time.Time, error) {
+	var v periodJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return v.Start, v.End, nil
+}
+
+// Implements encoding.TextMarshaler in the form of an ISO 8601 interval.
+func (s *Span) MarshalText() ([]byte, error) {
+	return []byte(FormatInterval(s)), nil
+}
+
+// Implements encoding.TextUnmarshaler with any form of ParseInterval, where a time without the offset is in UTC. It is validated in the same way as NewSpan.
+func (s *Span) UnmarshalText(text []byte) error {
+	start, end, err := ParseInterval(string(text), time.UTC)
+	if err != nil {
+		return err
+	}
+	span, err := NewSpan(start, end)
+	if err != nil {
+		return err
+	}
+	*s = *span
+	return nil
+}
+
+// Implements json.Marshaler in the form of {"start": ..., "end": ...}.
+func (s *Span) MarshalJSON() ([]byte, error) {
+	return marshalJSON(s)
+}
+
+// Implements json.Unmarshaler. It is validated in the same way as NewSpan.
+func (s *Span) UnmarshalJSON(data []byte) error {
+	start, end, err := unmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	span, err := NewSpan(start, end)
+	if err != nil {
+		return err
+	}
+	*s = *span
+	return nil
+}
+
+// Implements encoding.TextMarshaler in the form of an ISO 8601 interval. The buffer and units are not represented, so use JSON to keep them.
+func (b *Block) MarshalText() ([]byte, error) {
+	return []byte(FormatInterval(b)), nil
+}
+
+// Implements encoding.TextUnmarshaler with any form of ParseInterval, where a time without the offset is in UTC. It is validated in the same way as NewBlock.
+func (b *Block) UnmarshalText(text []byte) error {
+	start, end, err := ParseInterval(string(text), time.UTC)
+	if err != nil {
+		return err
+	}
+	block, err := NewBlock(start, end)
+	if err != nil {
+		return err
+	}
+	*b = *block
+	return nil
+}
+
+// Implements json.Marshaler in the form of {"start": ..., "end": ..., "buffer": {"before": "PT0S", "after": "PT1H"}, "units": 4}.
+// The buffer and units are present only when they are set to the Block.
+func (b *Block) MarshalJSON() ([]byte, error) {
+	v := blockJSON{
+		periodJSON: periodJSON{Start: b.start, End: b.end},
+		Units:      b.units,
+	}
+	if b.buffer != nil {
+		v.Buffer = &bufferJSON{
+			Before: FormatDuration(b.buffer.Before),
+			After:  FormatDuration(b.buffer.After),
+		}
+	}
+	return json.Marshal(v)
+}
+
+// Implements json.Unmarshaler. It is validated in the same way as NewBlock, and the buffer and units are restored.
+func (b *Block) UnmarshalJSON(data []byte) error {
+	var v blockJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	block, err := NewBlock(v.Start, v.End)
+	if err != nil {
+		return err
+	}
+	if v.Buffer != nil {
+		before, err := ParseDuration(v.Buffer.Before)
+		if err != nil {
+			return err
+		}
+		after, err := ParseDuration(v.Buffer.After)
+		if err != nil {
+			return err
+		}
+		block = block.WithBuffer(before, after)
+	}
+	if v.Units != nil {
+		block = block.WithUnits(*v.Units)
+	}
+	*b = *block
+	return nil
+}
+
+// Implements encoding.TextMarshaler in the form of an ISO 8601 interval.
+func (s *Slot) MarshalText() ([]byte, error) {
+	return []byte(FormatInterval(s)), nil
+}
+
+// Implements encoding.TextUnmarshaler with any form of ParseInterval, where a time without the offset is in UTC. It is validated in the same way as NewSlot.
+func (s *Slot) UnmarshalText(text []byte) error {
+	start, end, err := ParseInterval(string(text), time.UTC)
+	if err != nil {
+		return err
+	}
+	slot, err := NewSlot(start, end)
+	if err != nil {
+		return err
+	}
+	*s = *slot
+	return nil
+}
+
+// Implements json.Marshaler in the form of {"start": ..., "end": ...}.
+func (s *Slot) MarshalJSON() ([]byte, error) {
+	return marshalJSON(s)
+}
+
+// Implements json.Unmarshaler. It is validated in the same way as NewSlot.
+func (s *Slot) UnmarshalJSON(data []byte) error {
+	start, end, err := unmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	slot, err := NewSlot(start, end)
+	if err != nil {
+		return err
+	}
+	*s = *slot
+	return nil
+}
@@ -0,0 +1,149 @@
+package timeslots_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+	"timeslots"
+)
+
+func TestMarshalText(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	start := time.Date(2024, 9, 26, 19, 42, 44, 0, tokyo)
+	end := time.Date(2024, 9, 27, 3, 42, 44, 500, tokyo)
+	want := "2024-09-26T19:42:44+09:00/2024-09-27T03:42:44.0000005+09:00"
+
+	span, _ := timeslots.NewSpan(start, end)
+	block, _ := timeslots.NewBlock(start, end)
+	slot, _ := timeslots.NewSlot(start, end)
+
+	for name, v := range map[string]interface{ MarshalText() ([]byte, error) }{"Span": span, "Block": block, "Slot": slot} {
+		t.Run(name, func(t *testing.T) {
+			got, err := v.MarshalText()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != want {
+				t.Errorf("MarshalText() = %v, want %v", string(got), want)
+			}
+		})
+	}
+}
+
+func TestUnmarshalText(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{name: "Valid", text: "2024-09-26T19:00:00+09:00/2024-09-26T20:00:00Z", wantErr: false},
+		{name: "Start after end", text: "2024-09-26T20:00:00Z/2024-09-26T19:00:00Z", wantErr: true},
+		{name: "Without separator", text: "2024-09-26T19:00:00Z", wantErr: true},
+		{name: "Broken time", text: "2024-09-26 19:00:00/2024-09-26T20:00:00Z", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var span timeslots.Span
+			if err := span.UnmarshalText([]byte(tt.text)); (err != nil) != tt.wantErr {
+				t.Errorf("Span.UnmarshalText() error = %v, wantErr = %v", err, tt.wantErr)
+			}
+			var block timeslots.Block
+			if err := block.UnmarshalText([]byte(tt.text)); (err != nil) != tt.wantErr {
+				t.Errorf("Block.UnmarshalText() error = %v, wantErr = %v", err, tt.wantErr)
+			}
+			var slot timeslots.Slot
+			if err := slot.UnmarshalText([]byte(tt.text)); (err != nil) != tt.wantErr {
+				t.Errorf("Slot.UnmarshalText() error = %v, wantErr = %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	h := NewTestingHelper(time.Date(2024, 9, 26, 0, 0, 0, 0, time.UTC))
+
+	type payload struct {
+		Span   *timeslots.Span    `json:"span"`
+		Blocks []*timeslots.Block `json:"blocks"`
+		Slots  []*timeslots.Slot  `json:"slots"`
+	}
+	in := payload{
+		Span:   h.Span(0, 8),
+		Blocks: []*timeslots.Block{h.Block(1, 2)},
+		Slots:  []*timeslots.Slot{h.Slot(0, 1), h.Slot(2, 8)},
+	}
+
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"span":{"start":"2024-09-26T00:00:00Z","end":"2024-09-26T08:00:00Z"},` +
+		`"blocks":[{"start":"2024-09-26T01:00:00Z","end":"2024-09-26T02:00:00Z"}],` +
+		`"slots":[{"start":"2024-09-26T00:00:00Z","end":"2024-09-26T01:00:00Z"},{"start":"2024-09-26T02:00:00Z","end":"2024-09-26T08:00:00Z"}]}`
+	if string(data) != want {
+		t.Errorf("json.Marshal() = %v, want %v", string(data), want)
+	}
+
+	var out payload
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Span.String() != in.Span.String() || timeslots.ToString(out.Blocks) != timeslots.ToString(in.Blocks) || timeslots.ToString(out.Slots) != timeslots.ToString(in.Slots) {
+		t.Errorf("json.Unmarshal() = %+v, want %+v", out, in)
+	}
+
+	var span timeslots.Span
+	if err := json.Unmarshal([]byte(`{"start":"2024-09-26T08:00:00Z","end":"2024-09-26T00:00:00Z"}`), &span); err == nil {
+		t.Errorf("json.Unmarshal() error = nil, want error for start after end")
+	}
+
+	quorum := timeslots.FindQuorum(map[string][]*timeslots.Block{"alice": {}}, h.Span(0, 1), 1)
+	data, err = json.Marshal(quorum)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = `[{"start":"2024-09-26T00:00:00Z","end":"2024-09-26T01:00:00Z","available":["alice"],"busy":[]}]`
+	if string(data) != want {
+		t.Errorf("json.Marshal() = %v, want %v", string(data), want)
+	}
+}
+
+func TestBlockJSONBufferAndUnits(t *testing.T) {
+	h := NewTestingHelper(time.Date(2024, 9, 26, 0, 0, 0, 0, time.UTC))
+	in := []*timeslots.Block{
+		h.Block(1, 2).WithBuffer(0, 90*time.Minute).WithUnits(4),
+		h.Block(3, 4).WithUnits(0),
+		h.Block(5, 6),
+	}
+
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"start":"2024-09-26T01:00:00Z","end":"2024-09-26T02:00:00Z","buffer":{"before":"PT0S","after":"PT1H30M"},"units":4},` +
+		`{"start":"2024-09-26T03:00:00Z","end":"2024-09-26T04:00:00Z","units":0},` +
+		`{"start":"2024-09-26T05:00:00Z","end":"2024-09-26T06:00:00Z"}]`
+	if string(data) != want {
+		t.Errorf("json.Marshal() = %v, want %v", string(data), want)
+	}
+
+	var out []*timeslots.Block
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	for i := range in {
+		if out[i].String() != in[i].String() || out[i].Units() != in[i].Units() {
+			t.Errorf("block[%d] = %v (%d units), want %v (%d units)", i, out[i], out[i].Units(), in[i], in[i].Units())
+		}
+	}
+	// The restored buffer keeps the time after the first Block busy.
+	if got := timeslots.Find(out, h.Span(0, 3)); len(got) != 1 || !got[0].Equal(h.Slot(0, 1)) {
+		t.Errorf("Find() = %v, want the buffer to be restored", got)
+	}
+
+	var block timeslots.Block
+	if err := json.Unmarshal([]byte(`{"start":"2024-09-26T01:00:00Z","end":"2024-09-26T02:00:00Z","buffer":{"before":"1h","after":"PT0S"}}`), &block); err == nil {
+		t.Errorf("json.Unmarshal() error = nil, want error for an invalid buffer")
+	}
+}
@@ -0,0 +1,74 @@
+package timeslots
+
+import (
+	"slices"
+	"time"
+)
+
+// It returns the earliest Slot of the length starting at or after `from`. Provide the scheduled blocks (Block).
+// The horizon is the latest end time to look for, and a zero horizon means no limit.
+// The options are applied in the same way as Find, and the filter is evaluated on each free time after the alignment and duration options.
+func NextAvailable(blocks []*Block, from time.Time, length time.Duration, horizon time.Time, opts ...Option[*Slot]) (*Slot, bool) {
+	options := newOptions(opts)
+	blocks = pad(sortPeriods(blocks, options.Sort), options.Buffer)
+
+	end := horizon
+	if end.IsZero() {
+		end = from
+		for _, block := range blocks {
+			if block.end.After(end) {
+				end = block.end
+			}
+		}
+		end = end.Add(length)
+	}
+	if length < 0 || end.Before(from.Add(length)) {
+		return nil, false
+	}
+
+	var found *Slot
+	span := options.clip(newSpan(from, end))
+	_ = scan(slices.Values(blocks), span, options.location(span), func(s *Slot) bool {
+		return options.constrain(s, func(slot *Slot) bool {
+			if slot.end.Sub(slot.start) < length {
+				return true
+			}
+			if options.IsSetFilter() && options.FilterFunc(slot) {
+				return true
+			}
+			found = newSlot(slot.start, slot.start.Add(length))
+			return false
+		})
+	})
+	return found, found != nil
+}
+
+// It returns the latest Slot of the length ending at or before `before`. Provide the scheduled blocks (Block).
+// The horizon is the earliest start time to look for, and a zero horizon means no limit.
+// The options are applied in the same way as Find, and the filter is evaluated on each free time after the alignment and duration options.
+func PreviousAvailable(blocks []*Block, before time.Time, length time.Duration, horizon time.Time, opts ...Option[*Slot]) (*Slot, bool) {
+	options := newOptions(opts)
+	blocks = pad(sortPeriods(blocks, options.Sort), options.Buffer)
+
+	start := horizon
+	if start.IsZero() {
+		start = before
+		for _, block := range blocks {
+			if block.start.Before(start) {
+				start = block.start
+			}
+		}
+		start = start.Add(-length)
+	}
+	if length < 0 || before.Add(-length).Before(start) {
+		return nil, false
+	}
+
+	slots := search(blocks, newSpan(start, before), func(s *Slot) *Slot { return s }, options)
+	for i := len(slots) - 1; i >= 0; i-- {
+		if slots[i].end.Sub(slots[i].start) >= length {
+			return newSlot(slots[i].end.Add(-length), slots[i].end), true
+		}
+	}
+	return nil, false
+}
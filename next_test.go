@@ -0,0 +1,167 @@
+package timeslots_test
+
+import (
+	"testing"
+	"time"
+	"timeslots"
+)
+
+func TestNextAvailable(t *testing.T) {
+	h := NewTestingHelper(now)
+	blocks := []*timeslots.Block{h.Block(3, 5), h.Block(0, 1), h.Block(2, 3), h.Block(6, 7)}
+
+	tests := []struct {
+		name    string
+		from    time.Time
+		length  time.Duration
+		horizon time.Time
+		opts    []timeslots.Option[*timeslots.Slot]
+		want    *timeslots.Slot
+	}{
+		{
+			name:   "Right after a block",
+			from:   now,
+			length: time.Hour,
+			want:   h.Slot(1, 2),
+		},
+		{
+			name:   "Skip short gaps",
+			from:   now,
+			length: 90 * time.Minute,
+			want:   slotOf(now.Add(7*time.Hour), 90*time.Minute),
+		},
+		{
+			name:   "Free at the start",
+			from:   now.Add(5 * time.Hour),
+			length: time.Hour,
+			want:   h.Slot(5, 6),
+		},
+		{
+			name:    "Beyond the horizon",
+			from:    now,
+			length:  2 * time.Hour,
+			horizon: now.Add(8 * time.Hour),
+			want:    nil,
+		},
+		{
+			name:    "Within the horizon",
+			from:    now,
+			length:  30 * time.Minute,
+			horizon: now.Add(8 * time.Hour),
+			opts:    []timeslots.Option[*timeslots.Slot]{timeslots.WithBuffer[*timeslots.Slot](0, 30*time.Minute)},
+			want:    slotOf(now.Add(90*time.Minute), 30*time.Minute),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := timeslots.NextAvailable(blocks, tt.from, tt.length, tt.horizon, tt.opts...)
+			assertAvailable(t, got, ok, tt.want)
+		})
+	}
+}
+
+func TestPreviousAvailable(t *testing.T) {
+	h := NewTestingHelper(now)
+	blocks := []*timeslots.Block{h.Block(3, 5), h.Block(0, 1), h.Block(2, 3), h.Block(6, 7)}
+
+	tests := []struct {
+		name    string
+		before  time.Time
+		length  time.Duration
+		horizon time.Time
+		want    *timeslots.Slot
+	}{
+		{
+			name:   "Right before a block",
+			before: now.Add(7 * time.Hour),
+			length: time.Hour,
+			want:   h.Slot(5, 6),
+		},
+		{
+			name:   "Skip short gaps",
+			before: now.Add(7 * time.Hour),
+			length: 90 * time.Minute,
+			want:   slotOf(now.Add(-90*time.Minute), 90*time.Minute),
+		},
+		{
+			name:    "Beyond the horizon",
+			before:  now.Add(7 * time.Hour),
+			length:  90 * time.Minute,
+			horizon: now,
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := timeslots.PreviousAvailable(blocks, tt.before, tt.length, tt.horizon)
+			assertAvailable(t, got, ok, tt.want)
+		})
+	}
+}
+
+func assertAvailable(t *testing.T, got *timeslots.Slot, ok bool, want *timeslots.Slot) {
+	t.Helper()
+	if ok != (want != nil) {
+		t.Fatalf("ok = %v, want %v (got %v)", ok, want != nil, got)
+	}
+	if want != nil && !got.Equal(want) {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
+
+func slotOf(start time.Time, length time.Duration) *timeslots.Slot {
+	slot, _ := timeslots.NewSlot(start, start.Add(length))
+	return slot
+}
+
+func TestAvailableWithOptions(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, 9, 26, hour, minute, 0, 0, time.UTC)
+	}
+	blocks := []*timeslots.Block{
+		timeslots.NewBlockWithoutValidating(at(8, 0), at(9, 7)),
+		timeslots.NewBlockWithoutValidating(at(10, 40), at(12, 0)),
+	}
+
+	tests := []struct {
+		name         string
+		opts         []timeslots.Option[*timeslots.Slot]
+		wantNext     *timeslots.Slot
+		wantPrevious *timeslots.Slot
+	}{
+		{
+			name:         "No options",
+			wantNext:     slotOf(at(9, 7), 30*time.Minute),
+			wantPrevious: slotOf(at(10, 10), 30*time.Minute),
+		},
+		{
+			name:         "Alignment",
+			opts:         []timeslots.Option[*timeslots.Slot]{timeslots.WithAlignment[*timeslots.Slot](15*time.Minute, time.Time{})},
+			wantNext:     slotOf(at(9, 15), 30*time.Minute),
+			wantPrevious: slotOf(at(10, 0), 30*time.Minute),
+		},
+		{
+			name: "Maximum duration shorter than the length",
+			opts: []timeslots.Option[*timeslots.Slot]{timeslots.WithMaxDuration[*timeslots.Slot](10 * time.Minute)},
+		},
+		{
+			name: "Minimum duration longer than the free time",
+			opts: []timeslots.Option[*timeslots.Slot]{timeslots.WithMinDuration[*timeslots.Slot](2 * time.Hour)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, ok := timeslots.NextAvailable(blocks, at(8, 0), 30*time.Minute, at(12, 0), tt.opts...)
+			if ok != (tt.wantNext != nil) || (ok && !next.Equal(tt.wantNext)) {
+				t.Errorf("NextAvailable() = %v, %v, want %v", next, ok, tt.wantNext)
+			}
+			previous, ok := timeslots.PreviousAvailable(blocks, at(12, 0), 30*time.Minute, at(8, 0), tt.opts...)
+			if ok != (tt.wantPrevious != nil) || (ok && !previous.Equal(tt.wantPrevious)) {
+				t.Errorf("PreviousAvailable() = %v, %v, want %v", previous, ok, tt.wantPrevious)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package timeslots
+
+import (
+	"slices"
+	"sort"
+	"time"
+)
+
+// Options for NormalizeBlocks
+type NormalizeOptions struct {
+	Tolerance time.Duration
+}
+
+// Option Func for NormalizeBlocks
+type NormalizeOption func(*NormalizeOptions)
+
+// Run with the tolerance. Blocks separated by less than the tolerance are merged as well.
+func WithTolerance(tolerance time.Duration) NormalizeOption {
+	return func(opts *NormalizeOptions) {
+		opts.Tolerance = tolerance
+	}
+}
+
+// Sort and merge overlapping and adjacent Blocks. The given slice is not modified.
+// It also reports the indexes of the input Blocks merged into each output Block, in ascending order.
+// Only the Blocks with the same buffer are merged, and the merged Block keeps the buffer. The Blocks with units (WithUnits) are never merged,
+// because merging them would change the consumed capacity. The Blocks which are not merged keep their buffer and units.
+func NormalizeBlocks(blocks []*Block, opts ...NormalizeOption) ([]*Block, [][]int) {
+	options := NormalizeOptions{
+		Tolerance: 0,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	order := make([]int, len(blocks))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return blocks[order[i]].start.Before(blocks[order[j]].start)
+	})
+
+	// The last output Block of each buffer, which the next Block of the same buffer may be merged into.
+	type key struct {
+		buffered bool
+		buffer   Buffer
+	}
+	last := map[key]int{}
+
+	merged := []*Block{}
+	sources := [][]int{}
+	for _, i := range order {
+		block := blocks[i]
+		if block.units != nil {
+			copied := *block
+			merged = append(merged, &copied)
+			sources = append(sources, []int{i})
+			continue
+		}
+
+		k := key{buffered: block.buffer != nil}
+		if block.buffer != nil {
+			k.buffer = *block.buffer
+		}
+		if n, ok := last[k]; ok {
+			gap := block.start.Sub(merged[n].end)
+			if gap <= 0 || gap < options.Tolerance {
+				if block.end.After(merged[n].end) {
+					merged[n].end = block.end
+				}
+				sources[n] = append(sources[n], i)
+				continue
+			}
+		}
+		copied := *block
+		merged = append(merged, &copied)
+		sources = append(sources, []int{i})
+		last[k] = len(merged) - 1
+	}
+
+	for _, s := range sources {
+		slices.Sort(s)
+	}
+	return merged, sources
+}
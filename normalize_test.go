@@ -0,0 +1,99 @@
+package timeslots_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+	"timeslots"
+)
+
+func TestNormalizeBlocks(t *testing.T) {
+	h := NewTestingHelper(now)
+
+	tests := []struct {
+		name        string
+		blocks      []*timeslots.Block
+		opts        []timeslots.NormalizeOption
+		want        []*timeslots.Block
+		wantSources [][]int
+	}{
+		{
+			name:        "No blocks",
+			blocks:      []*timeslots.Block{},
+			want:        []*timeslots.Block{},
+			wantSources: [][]int{},
+		},
+		{
+			name:        "Overlapping, adjacent and unsorted blocks",
+			blocks:      []*timeslots.Block{h.Block(6, 7), h.Block(2, 4), h.Block(0, 3), h.Block(4, 5), h.Block(1, 2)},
+			want:        []*timeslots.Block{h.Block(0, 5), h.Block(6, 7)},
+			wantSources: [][]int{{1, 2, 3, 4}, {0}},
+		},
+		{
+			name:        "Contained block",
+			blocks:      []*timeslots.Block{h.Block(0, 8), h.Block(2, 3)},
+			want:        []*timeslots.Block{h.Block(0, 8)},
+			wantSources: [][]int{{0, 1}},
+		},
+		{
+			name:        "With tolerance",
+			blocks:      []*timeslots.Block{h.Block(0, 1), h.Block(2, 3), h.Block(5, 6)},
+			opts:        []timeslots.NormalizeOption{timeslots.WithTolerance(90 * time.Minute)},
+			want:        []*timeslots.Block{h.Block(0, 3), h.Block(5, 6)},
+			wantSources: [][]int{{0, 1}, {2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := make([]*timeslots.Block, len(tt.blocks))
+			copy(input, tt.blocks)
+
+			got, sources := timeslots.NormalizeBlocks(tt.blocks, tt.opts...)
+			if timeslots.ToString(got) != timeslots.ToString(tt.want) {
+				t.Errorf("got: %v, want: %v", timeslots.ToString(got), timeslots.ToString(tt.want))
+			}
+			if !reflect.DeepEqual(sources, tt.wantSources) {
+				t.Errorf("sources = %v, want %v", sources, tt.wantSources)
+			}
+			if !reflect.DeepEqual(input, tt.blocks) {
+				t.Errorf("input is modified")
+			}
+		})
+	}
+}
+
+func TestNormalizeBlocksKeepsBufferAndUnits(t *testing.T) {
+	h := NewTestingHelper(now)
+
+	blocks := []*timeslots.Block{
+		h.Block(0, 2).WithBuffer(0, 30*time.Minute),
+		h.Block(1, 3).WithBuffer(0, 30*time.Minute),
+		h.Block(2, 4),
+		h.Block(5, 6).WithUnits(3),
+		h.Block(5, 7).WithUnits(3),
+	}
+
+	got, sources := timeslots.NormalizeBlocks(blocks)
+	want := []*timeslots.Block{
+		h.Block(0, 3).WithBuffer(0, 30*time.Minute),
+		h.Block(2, 4),
+		h.Block(5, 6).WithUnits(3),
+		h.Block(5, 7).WithUnits(3),
+	}
+	gotJSON, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantJSON, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(gotJSON) != string(wantJSON) {
+		t.Errorf("got: %s, want: %s", gotJSON, wantJSON)
+	}
+	if wantSources := [][]int{{0, 1}, {2}, {3}, {4}}; !reflect.DeepEqual(sources, wantSources) {
+		t.Errorf("sources = %v, want %v", sources, wantSources)
+	}
+}
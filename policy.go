@@ -0,0 +1,119 @@
+package timeslots
+
+import (
+	"time"
+
+	"timeslots/internal/tz"
+)
+
+// This is the rule of when a resource can be booked, relative to the current time. (e.g. from 2 hours to 60 days ahead)
+type Policy struct {
+	minNotice  time.Duration
+	maxAdvance time.Duration
+	cutOff     *Clock
+	location   *time.Location
+	now        func() time.Time
+}
+
+// Option Func for Policy
+type PolicyOption func(*Policy)
+
+// Bookings must start at least the duration after the current time.
+func WithMinNotice(d time.Duration) PolicyOption {
+	return func(p *Policy) {
+		p.minNotice = d
+	}
+}
+
+// Bookings must end at most the duration after the current time.
+func WithMaxAdvance(d time.Duration) PolicyOption {
+	return func(p *Policy) {
+		p.maxAdvance = d
+	}
+}
+
+// Bookings for the next day close at the time of day. After it, the earliest booking is on the day after tomorrow.
+// The time of day and the days are interpreted on the wall clock of the location of the Policy.
+func WithCutOff(cutOff Clock) PolicyOption {
+	return func(p *Policy) {
+		p.cutOff = &cutOff
+	}
+}
+
+// Run with the function returning the current time instead of time.Now. (e.g. a fixed time in tests)
+func WithClock(now func() time.Time) PolicyOption {
+	return func(p *Policy) {
+		p.now = now
+	}
+}
+
+// Creates a new Policy for a resource in the location. Without options, any time from the current time can be booked.
+func NewPolicy(loc *time.Location, opts ...PolicyOption) *Policy {
+	if loc == nil {
+		loc = time.Local
+	}
+	p := &Policy{
+		location: loc,
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// The location of the Policy.
+func (p *Policy) Location() *time.Location {
+	return p.location
+}
+
+// The earliest start time and the latest end time which can be booked at the current time. A zero latest time means no limit.
+func (p *Policy) Window() (time.Time, time.Time) {
+	return p.window(p.now())
+}
+
+func (p *Policy) window(now time.Time) (time.Time, time.Time) {
+	earliest := now.Add(p.minNotice)
+	if p.cutOff != nil {
+		year, month, day := now.In(p.location).Date()
+		if !now.Before(p.cutOff.on(year, month, day, p.location)) {
+			if closed := tz.Date(year, month, day+2, 0, 0, 0, p.location); earliest.Before(closed) {
+				earliest = closed
+			}
+		}
+	}
+
+	var latest time.Time
+	if p.maxAdvance > 0 {
+		latest = now.Add(p.maxAdvance)
+	}
+	return earliest, latest
+}
+
+// Clip the Span to the period which can be booked at the current time. It returns a new Span, which is empty when nothing can be booked.
+func (p *Policy) Clip(span *Span) *Span {
+	if span == nil {
+		return nil
+	}
+	earliest, latest := p.window(p.now())
+	clipped := span.Clone()
+	if clipped.start.Before(earliest) {
+		clipped.start = earliest.In(span.Location())
+	}
+	if !latest.IsZero() && clipped.end.After(latest) {
+		clipped.end = latest.In(span.Location())
+	}
+	if clipped.end.Before(clipped.start) {
+		clipped.end = clipped.start
+	}
+	return clipped
+}
+
+// Whether the period can be booked at the current time.
+func (p *Policy) Allows(period Period) bool {
+	earliest, latest := p.window(p.now())
+	if period.Start().Before(earliest) {
+		return false
+	}
+	return latest.IsZero() || !period.End().After(latest)
+}
@@ -0,0 +1,99 @@
+package timeslots_test
+
+import (
+	"testing"
+	"time"
+	"timeslots"
+	"timeslots/internal/slice"
+)
+
+func TestPolicyClip(t *testing.T) {
+	newYork, _ := time.LoadLocation("America/New_York")
+	at := func(day, hour int) time.Time {
+		return time.Date(2024, time.September, day, hour, 0, 0, 0, newYork)
+	}
+	span := func(start, end time.Time) *timeslots.Span {
+		s, _ := timeslots.NewSpan(start, end)
+		return s
+	}
+	fixed := func(t time.Time) timeslots.PolicyOption {
+		// The clock of the server is in UTC, while the resource is in New York.
+		return timeslots.WithClock(func() time.Time { return t.UTC() })
+	}
+
+	tests := []struct {
+		name   string
+		policy *timeslots.Policy
+		search *timeslots.Span
+		want   *timeslots.Span
+	}{
+		{
+			name:   "Nil span",
+			policy: timeslots.NewPolicy(nil, fixed(at(10, 9))),
+			search: nil,
+			want:   nil,
+		},
+		{
+			name:   "The past cannot be booked",
+			policy: timeslots.NewPolicy(nil, fixed(at(10, 9))),
+			search: span(at(10, 0), at(20, 0)),
+			want:   span(at(10, 9), at(20, 0)),
+		},
+		{
+			name:   "Minimum notice and maximum advance",
+			policy: timeslots.NewPolicy(nil, fixed(at(10, 9)), timeslots.WithMinNotice(2*time.Hour), timeslots.WithMaxAdvance(5*24*time.Hour)),
+			search: span(at(10, 0), at(20, 0)),
+			want:   span(at(10, 11), at(15, 9)),
+		},
+		{
+			name:   "Before the cut-off",
+			policy: timeslots.NewPolicy(newYork, fixed(at(10, 16)), timeslots.WithCutOff(clock("17:00"))),
+			search: span(at(10, 0), at(20, 0)),
+			want:   span(at(10, 16), at(20, 0)),
+		},
+		{
+			name:   "After the cut-off",
+			policy: timeslots.NewPolicy(newYork, fixed(at(10, 17)), timeslots.WithCutOff(clock("17:00"))),
+			search: span(at(10, 0), at(20, 0)),
+			want:   span(at(12, 0), at(20, 0)),
+		},
+		{
+			name:   "Nothing can be booked",
+			policy: timeslots.NewPolicy(nil, fixed(at(10, 9)), timeslots.WithMinNotice(24*time.Hour)),
+			search: span(at(10, 0), at(11, 0)),
+			want:   span(at(11, 9), at(11, 9)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.policy.Clip(tt.search)
+			if (got == nil) != (tt.want == nil) || (got != nil && got.String() != tt.want.String()) {
+				t.Errorf("Clip() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindWithPolicy(t *testing.T) {
+	h := NewTestingHelper(now)
+	policy := timeslots.NewPolicy(nil,
+		timeslots.WithClock(func() time.Time { return now.Add(30 * time.Minute) }),
+		timeslots.WithMinNotice(90*time.Minute),
+		timeslots.WithMaxAdvance(6*time.Hour),
+	)
+	blocks := []*timeslots.Block{h.Block(3, 4)}
+
+	got := timeslots.Find(blocks, h.Span(0, 8), timeslots.WithPolicy[*timeslots.Slot](policy))
+	want := []*timeslots.Slot{h.Slot(2, 3), slotOf(now.Add(4*time.Hour), 150*time.Minute)}
+	if !slice.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", slice.String(got), slice.String(want))
+	}
+
+	if !policy.Allows(h.Slot(2, 3)) {
+		t.Errorf("Allows(%v) = false, want true", h.Slot(2, 3))
+	}
+	if policy.Allows(h.Slot(1, 3)) || policy.Allows(h.Slot(6, 7)) {
+		t.Error("Allows() = true for a slot out of the policy, want false")
+	}
+}
@@ -0,0 +1,33 @@
+package timeslots
+
+// Whether the Period is fully free, that is, no Block overlaps it. The buffers are applied in the same way as Find.
+func IsFree(blocks []*Block, p Period, opts ...Option[*Slot]) bool {
+	options := newOptions(opts)
+	for _, block := range blocks {
+		if block.padded(options.Buffer).Overlaps(p) {
+			return false
+		}
+	}
+	return true
+}
+
+// It returns the Blocks overlapping the Period, in the given order. (e.g. the bookings conflicting with a proposed one)
+// The buffers are applied in the same way as Find, and the Blocks are returned as given.
+func Conflicts(blocks []*Block, p Period, opts ...Option[*Slot]) []*Block {
+	options := newOptions(opts)
+	conflicts := []*Block{}
+	for _, block := range blocks {
+		if block.padded(options.Buffer).Overlaps(p) {
+			conflicts = append(conflicts, block)
+		}
+	}
+	return conflicts
+}
+
+// It returns the free portions of the Period. The options are applied in the same way as Find.
+func FreeWithin(blocks []*Block, p Period, opts ...Option[*Slot]) []*Slot {
+	if p.End().Before(p.Start()) {
+		return []*Slot{}
+	}
+	return Find(blocks, newSpan(p.Start(), p.End()), opts...)
+}
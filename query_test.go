@@ -0,0 +1,123 @@
+package timeslots_test
+
+import (
+	"testing"
+	"time"
+	"timeslots"
+	"timeslots/internal/slice"
+)
+
+func TestQuery(t *testing.T) {
+	h := NewTestingHelper(now)
+	blocks := []*timeslots.Block{h.Block(4, 5), h.Block(1, 2), h.Block(6, 9), h.Block(3, 3)}
+
+	tests := []struct {
+		name          string
+		period        timeslots.Period
+		wantFree      bool
+		wantConflicts []*timeslots.Block
+		wantSlots     []*timeslots.Slot
+	}{
+		{
+			name:          "Free between blocks",
+			period:        h.Slot(2, 4),
+			wantFree:      true,
+			wantConflicts: []*timeslots.Block{},
+			wantSlots:     []*timeslots.Slot{h.Slot(2, 3), h.Slot(3, 4)},
+		},
+		{
+			name:          "Overlap at start",
+			period:        h.Slot(1, 3),
+			wantFree:      false,
+			wantConflicts: []*timeslots.Block{h.Block(1, 2)},
+			wantSlots:     []*timeslots.Slot{h.Slot(2, 3)},
+		},
+		{
+			name:          "Contains blocks",
+			period:        h.Slot(0, 7),
+			wantFree:      false,
+			wantConflicts: []*timeslots.Block{h.Block(4, 5), h.Block(1, 2), h.Block(6, 9)},
+			wantSlots:     []*timeslots.Slot{h.Slot(0, 1), h.Slot(2, 3), h.Slot(3, 4), h.Slot(5, 6)},
+		},
+		{
+			name:          "Contained in a block",
+			period:        h.Slot(7, 8),
+			wantFree:      false,
+			wantConflicts: []*timeslots.Block{h.Block(6, 9)},
+			wantSlots:     []*timeslots.Slot{},
+		},
+		{
+			name:          "Touching the end of a block",
+			period:        h.Slot(9, 10),
+			wantFree:      true,
+			wantConflicts: []*timeslots.Block{},
+			wantSlots:     []*timeslots.Slot{h.Slot(9, 10)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := timeslots.IsFree(blocks, tt.period); got != tt.wantFree {
+				t.Errorf("IsFree() = %v, want %v", got, tt.wantFree)
+			}
+			if got := timeslots.Conflicts(blocks, tt.period); timeslots.ToString(got) != timeslots.ToString(tt.wantConflicts) {
+				t.Errorf("Conflicts() = %v, want %v", timeslots.ToString(got), timeslots.ToString(tt.wantConflicts))
+			}
+			if got := timeslots.FreeWithin(blocks, tt.period); !slice.Equal(got, tt.wantSlots) {
+				t.Errorf("FreeWithin() = %v, want %v", slice.String(got), slice.String(tt.wantSlots))
+			}
+		})
+	}
+}
+
+func TestQueryWithBuffer(t *testing.T) {
+	h := NewTestingHelper(now)
+	buffered := h.Block(1, 2).WithBuffer(0, time.Hour)
+	plain := h.Block(4, 5)
+	unbuffered := h.Block(7, 8).WithBuffer(0, 0)
+	blocks := []*timeslots.Block{buffered, plain, unbuffered}
+	opt := timeslots.WithBuffer[*timeslots.Slot](0, time.Hour)
+
+	tests := []struct {
+		name          string
+		period        timeslots.Period
+		opts          []timeslots.Option[*timeslots.Slot]
+		wantFree      bool
+		wantConflicts []*timeslots.Block
+	}{
+		{
+			name:          "Overlaps the buffer of a block",
+			period:        h.Slot(2, 3),
+			wantFree:      false,
+			wantConflicts: []*timeslots.Block{buffered},
+		},
+		{
+			name:          "Overlaps the buffer given as an option",
+			period:        h.Slot(5, 6),
+			opts:          []timeslots.Option[*timeslots.Slot]{opt},
+			wantFree:      false,
+			wantConflicts: []*timeslots.Block{plain},
+		},
+		{
+			name:          "The buffer of a block takes precedence",
+			period:        h.Slot(8, 9),
+			opts:          []timeslots.Option[*timeslots.Slot]{opt},
+			wantFree:      true,
+			wantConflicts: []*timeslots.Block{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := timeslots.IsFree(blocks, tt.period, tt.opts...); got != tt.wantFree {
+				t.Errorf("IsFree() = %v, want %v", got, tt.wantFree)
+			}
+			if got := timeslots.Conflicts(blocks, tt.period, tt.opts...); timeslots.ToString(got) != timeslots.ToString(tt.wantConflicts) {
+				t.Errorf("Conflicts() = %v, want %v", timeslots.ToString(got), timeslots.ToString(tt.wantConflicts))
+			}
+			if free := len(timeslots.FreeWithin(blocks, tt.period, tt.opts...)) == 1; free != tt.wantFree {
+				t.Errorf("FreeWithin() is fully free = %v, want %v", free, tt.wantFree)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package timeslots
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+)
+
+// This refers to available free time shared by some of the entities. It reports which entities are available during the whole Slot and which are busy during any part of it.
+type QuorumSlot[K cmp.Ordered] struct {
+	*Slot
+	Available []K
+	Busy      []K
+}
+
+// The JSON representation of a QuorumSlot.
+type quorumSlotJSON[K cmp.Ordered] struct {
+	periodJSON
+	Available []K `json:"available"`
+	Busy      []K `json:"busy"`
+}
+
+// Implements json.Marshaler in the form of {"start": ..., "end": ..., "available": [...], "busy": [...]}.
+func (q *QuorumSlot[K]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(quorumSlotJSON[K]{
+		periodJSON: periodJSON{Start: q.start, End: q.end},
+		Available:  q.Available,
+		Busy:       q.Busy,
+	})
+}
+
+// Implements json.Unmarshaler. It is validated in the same way as NewSlot.
+func (q *QuorumSlot[K]) UnmarshalJSON(data []byte) error {
+	var v quorumSlotJSON[K]
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	slot, err := NewSlot(v.Start, v.End)
+	if err != nil {
+		return err
+	}
+	q.Slot = slot
+	q.Available = v.Available
+	q.Busy = v.Busy
+	return nil
+}
+
+// Implements encoding.TextMarshaler to reject the text form, which cannot hold the entities. Use JSON instead.
+func (q *QuorumSlot[K]) MarshalText() ([]byte, error) {
+	return nil, errors.New("a QuorumSlot cannot be represented as text")
+}
+
+// Implements encoding.TextUnmarshaler to reject the text form, which cannot hold the entities. Use JSON instead.
+func (q *QuorumSlot[K]) UnmarshalText(text []byte) error {
+	return errors.New("a QuorumSlot cannot be represented as text")
+}
+
+// Calculate the time slots in which at least the given number (quorum) of entities are free. Provide the scheduled blocks (Block) of each entity keyed by any identifier, and the target period (Span).
+// Consecutive time slots in which the quorum is reached are merged into one, and they are returned in chronological order.
+// The available entities of a merged time slot are those free during the whole of it, so they can be fewer than the quorum when the free entities change within it.
+// The options are applied in the same way as Find, and the alignment and duration options are applied to each merged time slot.
+func FindQuorum[K cmp.Ordered](calendars map[K][]*Block, span *Span, quorum int, opts ...Option[*QuorumSlot[K]]) []*QuorumSlot[K] {
+	options := newOptions(opts)
+	span = options.clip(span)
+
+	if span == nil || !span.Remain() {
+		return []*QuorumSlot[K]{}
+	}
+
+	keys := make([]K, 0, len(calendars))
+	for key := range calendars {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	findOptions := []Option[*Slot]{}
+	if options.IsSetBuffer() {
+		findOptions = append(findOptions, WithBuffer[*Slot](options.Buffer.Before, options.Buffer.After))
+	}
+	frees := make([][]*Slot, len(keys))
+	boundaries := []time.Time{span.start, span.end}
+	for i, key := range keys {
+		frees[i] = Find(calendars[key], span, findOptions...)
+		for _, free := range frees[i] {
+			boundaries = append(boundaries, free.start, free.end)
+		}
+	}
+	slices.SortFunc(boundaries, func(a, b time.Time) int {
+		return a.Compare(b)
+	})
+	boundaries = slices.CompactFunc(boundaries, func(a, b time.Time) bool {
+		return a.Equal(b)
+	})
+
+	slots := []*QuorumSlot[K]{}
+	cursors := make([]int, len(keys))
+	var last *QuorumSlot[K]
+	for i := 0; i < len(boundaries)-1; i++ {
+		start, end := boundaries[i], boundaries[i+1]
+		available, busy := []K{}, []K{}
+		for j, key := range keys {
+			for cursors[j] < len(frees[j]) && beforeEq(frees[j][cursors[j]].end, start) {
+				cursors[j]++
+			}
+			if cursors[j] < len(frees[j]) && beforeEq(frees[j][cursors[j]].start, start) {
+				available = append(available, key)
+				continue
+			}
+			busy = append(busy, key)
+		}
+
+		if len(available) < quorum {
+			continue
+		}
+		if last != nil && last.end.Equal(start) {
+			last.end = end
+			last.Available = slices.DeleteFunc(last.Available, func(key K) bool {
+				return !slices.Contains(available, key)
+			})
+			for _, key := range busy {
+				if !slices.Contains(last.Busy, key) {
+					last.Busy = append(last.Busy, key)
+				}
+			}
+			slices.Sort(last.Busy)
+			continue
+		}
+		last = &QuorumSlot[K]{
+			Slot:      newSlot(start, end),
+			Available: available,
+			Busy:      busy,
+		}
+		slots = append(slots, last)
+	}
+
+	loc := options.location(span)
+	found := []*QuorumSlot[K]{}
+	for _, slot := range slots {
+		slot.localize(loc)
+		options.constrain(slot.Slot, func(s *Slot) bool {
+			q := &QuorumSlot[K]{Slot: s, Available: slot.Available, Busy: slot.Busy}
+			if options.IsSetFilter() && options.FilterFunc(q) {
+				return true
+			}
+			found = append(found, q)
+			return true
+		})
+	}
+	return found
+}
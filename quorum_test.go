@@ -0,0 +1,200 @@
+package timeslots_test
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+	"timeslots"
+)
+
+func TestFindQuorum(t *testing.T) {
+	h := NewTestingHelper(now)
+
+	type want struct {
+		slot      *timeslots.Slot
+		available []string
+		busy      []string
+	}
+
+	tests := []struct {
+		name      string
+		calendars map[string][]*timeslots.Block
+		search    *timeslots.Span
+		quorum    int
+		filter    timeslots.FilterFunc[*timeslots.QuorumSlot[string]]
+		want      []want
+	}{
+		{
+			name:      "Nil span",
+			calendars: map[string][]*timeslots.Block{"alice": {}},
+			search:    nil,
+			quorum:    1,
+			want:      []want{},
+		},
+		{
+			name: "Everyone is free",
+			calendars: map[string][]*timeslots.Block{
+				"alice": {},
+				"bob":   {h.Block(9, 10)},
+			},
+			search: h.Span(0, 8),
+			quorum: 2,
+			want: []want{
+				{slot: h.Slot(0, 8), available: []string{"alice", "bob"}, busy: []string{}},
+			},
+		},
+		{
+			name: "Two of three",
+			calendars: map[string][]*timeslots.Block{
+				"alice": {h.Block(1, 3)},
+				"bob":   {h.Block(2, 4)},
+				"carol": {h.Block(6, 8)},
+			},
+			search: h.Span(0, 8),
+			quorum: 2,
+			want: []want{
+				{slot: h.Slot(0, 2), available: []string{"bob", "carol"}, busy: []string{"alice"}},
+				{slot: h.Slot(3, 8), available: []string{"alice"}, busy: []string{"bob", "carol"}},
+			},
+		},
+		{
+			name: "Quorum is not reached",
+			calendars: map[string][]*timeslots.Block{
+				"alice": {h.Block(0, 4)},
+				"bob":   {h.Block(2, 8)},
+			},
+			search: h.Span(0, 8),
+			quorum: 2,
+			want:   []want{},
+		},
+		{
+			name: "Two of three with filter",
+			calendars: map[string][]*timeslots.Block{
+				"alice": {h.Block(1, 3)},
+				"bob":   {h.Block(2, 4)},
+				"carol": {h.Block(6, 8)},
+			},
+			search: h.Span(0, 8),
+			quorum: 2,
+			filter: func(s *timeslots.QuorumSlot[string]) bool {
+				return s.End().Sub(s.Start()) < (3 * time.Hour)
+			},
+			want: []want{
+				{slot: h.Slot(3, 8), available: []string{"alice"}, busy: []string{"bob", "carol"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []timeslots.Option[*timeslots.QuorumSlot[string]]{}
+			if tt.filter != nil {
+				opts = append(opts, timeslots.WithFilter(tt.filter))
+			}
+			got := timeslots.FindQuorum(tt.calendars, tt.search, tt.quorum, opts...)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d slots, want %d slots", len(got), len(tt.want))
+			}
+			for i, w := range tt.want {
+				if !got[i].Slot.Equal(w.slot) {
+					t.Errorf("slot[%d] = %v, want %v", i, got[i].Slot, w.slot)
+				}
+				if !slices.Equal(got[i].Available, w.available) {
+					t.Errorf("slot[%d] available = %v, want %v", i, got[i].Available, w.available)
+				}
+				if !slices.Equal(got[i].Busy, w.busy) {
+					t.Errorf("slot[%d] busy = %v, want %v", i, got[i].Busy, w.busy)
+				}
+			}
+		})
+	}
+}
+
+func TestFindQuorumMinDuration(t *testing.T) {
+	h := NewTestingHelper(now)
+	calendars := map[string][]*timeslots.Block{
+		"alice": {},
+		"bob":   {},
+		"carol": {h.Block(1, 2)},
+	}
+
+	got := timeslots.FindQuorum(calendars, h.Span(0, 4), 2,
+		timeslots.WithMinDuration[*timeslots.QuorumSlot[string]](4*time.Hour),
+	)
+	if len(got) != 1 {
+		t.Fatalf("got %d slots, want 1 slot", len(got))
+	}
+	if !got[0].Slot.Equal(h.Slot(0, 4)) {
+		t.Errorf("slot = %v, want %v", got[0].Slot, h.Slot(0, 4))
+	}
+	if want := []string{"alice", "bob"}; !slices.Equal(got[0].Available, want) {
+		t.Errorf("available = %v, want %v", got[0].Available, want)
+	}
+	if want := []string{"carol"}; !slices.Equal(got[0].Busy, want) {
+		t.Errorf("busy = %v, want %v", got[0].Busy, want)
+	}
+}
+
+func TestQuorumSlotUnmarshalJSON(t *testing.T) {
+	h := NewTestingHelper(time.Date(2024, 9, 26, 0, 0, 0, 0, time.UTC))
+	calendars := map[string][]*timeslots.Block{
+		"alice": {h.Block(1, 2)},
+		"bob":   {},
+	}
+	slots := timeslots.FindQuorum(calendars, h.Span(0, 3), 1)
+
+	data, err := json.Marshal(slots)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []*timeslots.QuorumSlot[string]
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(slots) {
+		t.Fatalf("got %d slots, want %d slots", len(got), len(slots))
+	}
+	for i, slot := range slots {
+		if !got[i].Slot.Equal(slot.Slot) || !slices.Equal(got[i].Available, slot.Available) || !slices.Equal(got[i].Busy, slot.Busy) {
+			t.Errorf("slot[%d] = %v %v %v, want %v %v %v", i, got[i].Slot, got[i].Available, got[i].Busy, slot.Slot, slot.Available, slot.Busy)
+		}
+	}
+
+	var inverted timeslots.QuorumSlot[string]
+	if err := json.Unmarshal([]byte(`{"start":"2024-09-26T01:00:00Z","end":"2024-09-26T00:00:00Z","available":[],"busy":[]}`), &inverted); !errors.Is(err, timeslots.ErrInvertedPeriod) {
+		t.Errorf("json.Unmarshal() error = %v, want %v", err, timeslots.ErrInvertedPeriod)
+	}
+	if _, err := slots[0].MarshalText(); err == nil {
+		t.Errorf("MarshalText() error = nil, want an error")
+	}
+	if err := inverted.UnmarshalText([]byte("2024-09-26T00:00:00Z/2024-09-26T01:00:00Z")); err == nil {
+		t.Errorf("UnmarshalText() error = nil, want an error")
+	}
+}
+
+func TestFindQuorumOptions(t *testing.T) {
+	h := NewTestingHelper(now)
+	calendars := map[string][]*timeslots.Block{
+		"alice": {h.Block(5, 6)},
+		"bob":   {},
+	}
+	policy := timeslots.NewPolicy(nil, timeslots.WithClock(func() time.Time { return now.Add(2 * time.Hour) }))
+
+	got := timeslots.FindQuorum(calendars, h.Span(0, 8), 2,
+		timeslots.WithPolicy[*timeslots.QuorumSlot[string]](policy),
+		timeslots.WithMaxDuration[*timeslots.QuorumSlot[string]](2*time.Hour),
+		timeslots.WithMinDuration[*timeslots.QuorumSlot[string]](90*time.Minute),
+		timeslots.WithBuffer[*timeslots.QuorumSlot[string]](0, time.Hour),
+	)
+	want := []*timeslots.Slot{h.Slot(2, 4)}
+	if len(got) != len(want) {
+		t.Fatalf("got %d slots, want %d slots", len(got), len(want))
+	}
+	for i, w := range want {
+		if !got[i].Slot.Equal(w) || !slices.Equal(got[i].Available, []string{"alice", "bob"}) {
+			t.Errorf("slot[%d] = %v %v, want %v", i, got[i].Slot, got[i].Available, w)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+// Package recurrence expands recurring events of RFC 5545 (RRULE, RDATE and EXDATE) into Blocks.
+package recurrence
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"timeslots"
+	"timeslots/internal/ical"
+)
+
+// This is a recurring event. Each occurrence starts at the time given by DTSTART, the rules and RDATE, except EXDATE, and lasts for the Duration.
+type Recurrence struct {
+	Start    time.Time
+	Duration time.Duration
+	Rules    []*Rule
+	RDates   []time.Time
+	ExDates  []time.Time
+}
+
+// Parse the content lines of DTSTART, DTEND, DURATION, RRULE, RDATE and EXDATE. Floating times are in the local time zone.
+//
+//	DTSTART;TZID=Asia/Tokyo:20240903T100000
+//	DURATION:PT1H
+//	RRULE:FREQ=WEEKLY;BYDAY=TU;UNTIL=20241231T235959Z
+//	EXDATE;TZID=Asia/Tokyo:20240924T100000
+func Parse(text string) (*Recurrence, error) {
+	return ParseInLocation(text, time.Local)
+}
+
+// Parse the content lines like Parse, but floating times are in the given location.
+func ParseInLocation(text string, loc *time.Location) (*Recurrence, error) {
+	lines, err := ical.ReadLines(strings.NewReader(text))
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Recurrence{}
+	var end time.Time
+	allDay := false
+	hasDuration := false
+	for _, line := range lines {
+		property, err := ical.ParseProperty(line)
+		if err != nil {
+			return nil, err
+		}
+		location, err := ical.Location(property.Params, loc)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", property.Name, err)
+		}
+
+		switch property.Name {
+		case "DTSTART":
+			r.Start, allDay, err = ical.ParseTime(property.Value, location)
+		case "DTEND":
+			end, _, err = ical.ParseTime(property.Value, location)
+		case "DURATION":
+			r.Duration, err = ical.ParseDuration(property.Value)
+			hasDuration = true
+		case "RRULE":
+			var rule *Rule
+			rule, err = ParseRule(property.Value, location)
+			r.Rules = append(r.Rules, rule)
+		case "RDATE":
+			var dates []time.Time
+			dates, err = parseDates(property, location)
+			r.RDates = append(r.RDates, dates...)
+		case "EXDATE":
+			var dates []time.Time
+			dates, err = parseDates(property, location)
+			r.ExDates = append(r.ExDates, dates...)
+		default:
+			err = fmt.Errorf("unsupported property")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", property.Name, err)
+		}
+	}
+
+	if r.Start.IsZero() {
+		return nil, fmt.Errorf("DTSTART is required")
+	}
+	switch {
+	case !end.IsZero() && hasDuration:
+		return nil, fmt.Errorf("DTEND and DURATION must not occur together")
+	case !end.IsZero():
+		r.Duration = end.Sub(r.Start)
+	case !hasDuration && allDay:
+		r.Duration = 24 * time.Hour
+	}
+	if r.Duration < 0 {
+		return nil, fmt.Errorf("the end is before the start")
+	}
+	return r, nil
+}
+
+func parseDates(property *ical.Property, loc *time.Location) ([]time.Time, error) {
+	if value, ok := property.Params["VALUE"]; ok && value == "PERIOD" {
+		return nil, fmt.Errorf("PERIOD is not supported")
+	}
+	return ical.ParseTimes(property.Value, loc)
+}
+
+// The start times of the occurrences overlapping the period from `from` to `to`, in chronological order.
+// The rules are evaluated only until `to`, so an endless rule is safe.
+func (r *Recurrence) Between(from, to time.Time) []time.Time {
+	starts := []time.Time{r.Start}
+	for _, rule := range r.Rules {
+		rule.iterate(r.Start, to, func(t time.Time) bool {
+			if t.Add(r.Duration).After(from) {
+				starts = append(starts, t)
+			}
+			return true
+		})
+	}
+	starts = append(starts, r.RDates...)
+
+	slices.SortFunc(starts, func(a, b time.Time) int {
+		return a.Compare(b)
+	})
+	starts = slices.CompactFunc(starts, func(a, b time.Time) bool {
+		return a.Equal(b)
+	})
+
+	occurrences := []time.Time{}
+	for _, start := range starts {
+		if !start.Before(to) || !start.Add(r.Duration).After(from) {
+			continue
+		}
+		if slices.ContainsFunc(r.ExDates, start.Equal) {
+			continue
+		}
+		occurrences = append(occurrences, start)
+	}
+	return occurrences
+}
+
+// Expand the occurrences into Blocks clipped to the Span, so they can be passed to timeslots.Find.
+func (r *Recurrence) Blocks(span *timeslots.Span) []*timeslots.Block {
+	if span == nil {
+		return []*timeslots.Block{}
+	}
+
+	occurrences := r.Between(span.Start(), span.End())
+	blocks := make([]*timeslots.Block, len(occurrences))
+	for i, start := range occurrences {
+		end := start.Add(r.Duration)
+		if start.Before(span.Start()) {
+			start = span.Start()
+		}
+		if end.After(span.End()) {
+			end = span.End()
+		}
+		blocks[i] = timeslots.NewBlockWithoutValidating(start, end)
+	}
+	return blocks
+}
@@ -0,0 +1,111 @@
+package recurrence_test
+
+import (
+	"testing"
+	"time"
+
+	"timeslots"
+	"timeslots/recurrence"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name         string
+		text         string
+		wantDuration time.Duration
+		wantErr      bool
+	}{
+		{
+			name:         "With duration",
+			text:         "DTSTART;TZID=Asia/Tokyo:20240903T100000\nDURATION:PT1H30M\nRRULE:FREQ=WEEKLY",
+			wantDuration: 90 * time.Minute,
+		},
+		{
+			name:         "With end",
+			text:         "DTSTART:20240903T100000Z\nDTEND:20240903T110000Z\nRRULE:FREQ=DAILY\nEXDATE:20240904T100000Z",
+			wantDuration: time.Hour,
+		},
+		{
+			name:         "All day",
+			text:         "DTSTART;VALUE=DATE:20240903\nRRULE:FREQ=YEARLY",
+			wantDuration: 24 * time.Hour,
+		},
+		{
+			name:    "Missing start",
+			text:    "RRULE:FREQ=DAILY",
+			wantErr: true,
+		},
+		{
+			name:    "End before start",
+			text:    "DTSTART:20240903T100000Z\nDTEND:20240903T090000Z",
+			wantErr: true,
+		},
+		{
+			name:    "Unknown time zone",
+			text:    "DTSTART;TZID=Nowhere/Never:20240903T100000",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid rule",
+			text:    "DTSTART:20240903T100000Z\nRRULE:FREQ=NEVER",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := recurrence.ParseInLocation(tt.text, time.UTC)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseInLocation() error = %v, wantErr = %v", err, tt.wantErr)
+			}
+			if err == nil && r.Duration != tt.wantDuration {
+				t.Errorf("Duration = %v, want %v", r.Duration, tt.wantDuration)
+			}
+		})
+	}
+}
+
+func TestBetween(t *testing.T) {
+	r, err := recurrence.Parse("DTSTART:20240903T100000Z\nDURATION:PT1H\nRRULE:FREQ=DAILY;COUNT=5\nRDATE:20240910T100000Z\nEXDATE:20240904T100000Z,20240906T100000Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := r.Between(time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC))
+	assertTimes(t, got, []string{"2024-09-03T10:00", "2024-09-05T10:00", "2024-09-07T10:00", "2024-09-10T10:00"})
+}
+
+func TestBlocks(t *testing.T) {
+	r, err := recurrence.Parse("DTSTART;TZID=Asia/Tokyo:20240903T100000\nDURATION:PT1H\nRRULE:FREQ=WEEKLY;BYDAY=TU")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 9, day, hour, minute, 0, 0, tokyo)
+	}
+
+	span, _ := timeslots.NewSpan(at(10, 10, 30), at(24, 10, 0))
+	blocks := r.Blocks(span)
+	want := [][2]time.Time{
+		{at(10, 10, 30), at(10, 11, 0)},
+		{at(17, 10, 0), at(17, 11, 0)},
+	}
+	if len(blocks) != len(want) {
+		t.Fatalf("got %d blocks: %v, want %d blocks", len(blocks), timeslots.ToString(blocks), len(want))
+	}
+	for i, w := range want {
+		if !blocks[i].Start().Equal(w[0]) || !blocks[i].End().Equal(w[1]) {
+			t.Errorf("block[%d] = %v, want %v - %v", i, blocks[i], w[0], w[1])
+		}
+	}
+
+	slots := timeslots.Find(blocks, span)
+	if len(slots) != 2 {
+		t.Errorf("got %d slots: %v, want 2 slots", len(slots), timeslots.ToString(slots))
+	}
+
+	if got := r.Blocks(nil); len(got) != 0 {
+		t.Errorf("got %d blocks for nil span, want 0", len(got))
+	}
+}
@@ -0,0 +1,476 @@
+package recurrence
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"timeslots/internal/ical"
+	"timeslots/internal/tz"
+)
+
+// The FREQ rule part.
+type Frequency int
+
+const (
+	Minutely Frequency = iota
+	Hourly
+	Daily
+	Weekly
+	Monthly
+	Yearly
+)
+
+var frequencies = map[string]Frequency{
+	"MINUTELY": Minutely,
+	"HOURLY":   Hourly,
+	"DAILY":    Daily,
+	"WEEKLY":   Weekly,
+	"MONTHLY":  Monthly,
+	"YEARLY":   Yearly,
+}
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// A weekday of the BYDAY rule part. N is the ordinal such as 2 of `2MO` or -1 of `-1FR`, and 0 means every week.
+type Weekday struct {
+	Weekday time.Weekday
+	N       int
+}
+
+// This is a recurrence rule (RRULE) of RFC 5545. BYWEEKNO is not supported.
+type Rule struct {
+	Frequency  Frequency
+	Interval   int
+	Count      int
+	Until      time.Time
+	ByMonth    []time.Month
+	ByYearDay  []int
+	ByMonthDay []int
+	ByDay      []Weekday
+	ByHour     []int
+	ByMinute   []int
+	BySecond   []int
+	BySetPos   []int
+	WeekStart  time.Weekday
+}
+
+// Parse the value of RRULE such as `FREQ=WEEKLY;BYDAY=TU;UNTIL=20241231T235959Z`. The `RRULE:` prefix is allowed.
+// The location is used for UNTIL without the `Z` suffix.
+func ParseRule(value string, loc *time.Location) (*Rule, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	rule := &Rule{
+		Interval:  1,
+		WeekStart: time.Monday,
+	}
+
+	hasFrequency := false
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid rule part: %q", part)
+		}
+
+		var err error
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Frequency, ok = frequencies[strings.ToUpper(val)]
+			if !ok {
+				return nil, fmt.Errorf("unsupported frequency: %q", val)
+			}
+			hasFrequency = true
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(val)
+			if err == nil && rule.Interval < 1 {
+				err = fmt.Errorf("interval must be positive")
+			}
+		case "COUNT":
+			rule.Count, err = strconv.Atoi(val)
+			if err == nil && rule.Count < 1 {
+				err = fmt.Errorf("count must be positive")
+			}
+		case "UNTIL":
+			rule.Until, _, err = ical.ParseTime(val, loc)
+		case "BYMONTH":
+			var months []int
+			months, err = parseInts(val, 1, 12, false)
+			for _, m := range months {
+				rule.ByMonth = append(rule.ByMonth, time.Month(m))
+			}
+		case "BYYEARDAY":
+			rule.ByYearDay, err = parseInts(val, 1, 366, true)
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseInts(val, 1, 31, true)
+		case "BYDAY":
+			rule.ByDay, err = parseWeekdays(val)
+		case "BYHOUR":
+			rule.ByHour, err = parseInts(val, 0, 23, false)
+		case "BYMINUTE":
+			rule.ByMinute, err = parseInts(val, 0, 59, false)
+		case "BYSECOND":
+			rule.BySecond, err = parseInts(val, 0, 59, false)
+		case "BYSETPOS":
+			rule.BySetPos, err = parseInts(val, 1, 366, true)
+		case "WKST":
+			rule.WeekStart, ok = weekdays[strings.ToUpper(val)]
+			if !ok {
+				err = fmt.Errorf("unknown weekday")
+			}
+		default:
+			return nil, fmt.Errorf("unsupported rule part: %q", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %q: %w", key, val, err)
+		}
+	}
+
+	if !hasFrequency {
+		return nil, fmt.Errorf("FREQ is required: %q", value)
+	}
+	if rule.Count > 0 && !rule.Until.IsZero() {
+		return nil, fmt.Errorf("COUNT and UNTIL must not occur together: %q", value)
+	}
+	slices.Sort(rule.ByHour)
+	slices.Sort(rule.ByMinute)
+	slices.Sort(rule.BySecond)
+	return rule, nil
+}
+
+func parseInts(value string, min, max int, negative bool) ([]int, error) {
+	ints := []int{}
+	for _, v := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, err
+		}
+		abs := n
+		if negative && n < 0 {
+			abs = -n
+		}
+		if abs < min || abs > max {
+			return nil, fmt.Errorf("out of range: %d", n)
+		}
+		ints = append(ints, n)
+	}
+	return ints, nil
+}
+
+func parseWeekdays(value string) ([]Weekday, error) {
+	days := []Weekday{}
+	for _, v := range strings.Split(value, ",") {
+		v = strings.ToUpper(v)
+		if len(v) < 2 {
+			return nil, fmt.Errorf("unknown weekday: %q", v)
+		}
+		weekday, ok := weekdays[v[len(v)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("unknown weekday: %q", v)
+		}
+		n := 0
+		if len(v) > 2 {
+			var err error
+			n, err = strconv.Atoi(v[:len(v)-2])
+			if err != nil || n == 0 || n < -53 || n > 53 {
+				return nil, fmt.Errorf("invalid ordinal: %q", v)
+			}
+		}
+		days = append(days, Weekday{Weekday: weekday, N: n})
+	}
+	return days, nil
+}
+
+// Iterate the occurrences of the rule from dtstart in chronological order, until the limit is reached or yield returns false.
+func (r *Rule) iterate(dtstart, limit time.Time, yield func(time.Time) bool) {
+	count := 0
+	for k := 0; ; k++ {
+		start, candidates := r.period(dtstart, k*r.Interval)
+		if !start.Before(limit) || (!r.Until.IsZero() && start.After(r.Until)) {
+			return
+		}
+
+		for _, c := range r.setPos(candidates) {
+			if c.Before(dtstart) {
+				continue
+			}
+			if !r.Until.IsZero() && c.After(r.Until) {
+				return
+			}
+			if !c.Before(limit) {
+				return
+			}
+			count++
+			if !yield(c) {
+				return
+			}
+			if r.Count > 0 && count >= r.Count {
+				return
+			}
+		}
+	}
+}
+
+// Calculate the start of the n-th period from dtstart and the candidates in the period.
+func (r *Rule) period(dtstart time.Time, n int) (time.Time, []time.Time) {
+	loc := dtstart.Location()
+	year, month, day := dtstart.Date()
+
+	// The periods shorter than a day are counted on the instants, so the hour repeated by DST is not skipped.
+	seconds := time.Duration(dtstart.Second())*time.Second + time.Duration(dtstart.Nanosecond())
+	switch r.Frequency {
+	case Minutely:
+		start := dtstart.Add(-seconds).Add(time.Duration(n) * time.Minute)
+		return start, r.subdaily(start, dtstart, time.Minute)
+	case Hourly:
+		start := dtstart.Add(-seconds - time.Duration(dtstart.Minute())*time.Minute).Add(time.Duration(n) * time.Hour)
+		return start, r.subdaily(start, dtstart, time.Hour)
+	}
+
+	var first time.Time
+	days := 0
+	switch r.Frequency {
+	case Yearly:
+		first = time.Date(year+n, time.January, 1, 0, 0, 0, 0, time.UTC)
+		days = first.AddDate(1, 0, -1).YearDay()
+	case Monthly:
+		first = time.Date(year, month+time.Month(n), 1, 0, 0, 0, 0, time.UTC)
+		days = daysIn(first)
+	case Weekly:
+		date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		offset := (int(date.Weekday()) - int(r.WeekStart) + 7) % 7
+		first = date.AddDate(0, 0, 7*n-offset)
+		days = 7
+	case Daily:
+		first = time.Date(year, month, day+n, 0, 0, 0, 0, time.UTC)
+		days = 1
+	}
+
+	candidates := []time.Time{}
+	for i := 0; i < days; i++ {
+		date := first.AddDate(0, 0, i)
+		if !r.matchDate(date, dtstart) {
+			continue
+		}
+		for _, hour := range or(r.ByHour, dtstart.Hour()) {
+			for _, minute := range or(r.ByMinute, dtstart.Minute()) {
+				for _, second := range or(r.BySecond, dtstart.Second()) {
+					candidates = append(candidates, tz.Date(date.Year(), date.Month(), date.Day(), hour, minute, second, loc))
+				}
+			}
+		}
+	}
+	return time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, loc), candidates
+}
+
+// Calculate the candidates within a minute or an hour starting at `start`. The minute and second default to those of dtstart.
+// The candidates are offsets from `start`, so they stay in the same instance of an hour repeated by DST.
+func (r *Rule) subdaily(start, dtstart time.Time, unit time.Duration) []time.Time {
+	year, month, day := start.Date()
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	if !r.matchDate(date, start) || !matchInt(r.ByHour, start.Hour()) {
+		return []time.Time{}
+	}
+
+	candidates := []time.Time{}
+	minutes := []int{start.Minute()}
+	if unit == time.Hour {
+		minutes = or(r.ByMinute, dtstart.Minute())
+	}
+	if unit == time.Minute && !matchInt(r.ByMinute, start.Minute()) {
+		return candidates
+	}
+	for _, minute := range minutes {
+		for _, second := range or(r.BySecond, dtstart.Second()) {
+			offset := time.Duration(second) * time.Second
+			if unit == time.Hour {
+				offset += time.Duration(minute) * time.Minute
+			}
+			candidates = append(candidates, start.Add(offset))
+		}
+	}
+	return candidates
+}
+
+// Whether the date (in UTC) matches the BYMONTH, BYYEARDAY, BYMONTHDAY and BYDAY rule parts.
+func (r *Rule) matchDate(date, dtstart time.Time) bool {
+	if len(r.ByMonth) > 0 && !slices.Contains(r.ByMonth, date.Month()) {
+		return false
+	}
+
+	yearDay, yearDays := date.YearDay(), time.Date(date.Year(), time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+	monthDay, monthDays := date.Day(), daysIn(date)
+
+	if len(r.ByYearDay) > 0 && !matchOrdinal(r.ByYearDay, yearDay, yearDays) {
+		return false
+	}
+	if len(r.ByMonthDay) > 0 && !matchOrdinal(r.ByMonthDay, monthDay, monthDays) {
+		return false
+	}
+	if len(r.ByDay) > 0 {
+		matched := false
+		for _, wd := range r.ByDay {
+			if wd.Weekday != date.Weekday() {
+				continue
+			}
+			switch {
+			case wd.N == 0 || r.Frequency < Monthly:
+				matched = true
+			case r.Frequency == Monthly || len(r.ByMonth) > 0:
+				matched = matched || wd.N == (monthDay-1)/7+1 || wd.N == -((monthDays-monthDay)/7+1)
+			default:
+				matched = matched || wd.N == (yearDay-1)/7+1 || wd.N == -((yearDays-yearDay)/7+1)
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if len(r.ByYearDay) > 0 || len(r.ByMonthDay) > 0 || len(r.ByDay) > 0 {
+		return true
+	}
+	switch r.Frequency {
+	case Yearly:
+		if len(r.ByMonth) == 0 && date.Month() != dtstart.Month() {
+			return false
+		}
+		return monthDay == dtstart.Day()
+	case Monthly:
+		return monthDay == dtstart.Day()
+	case Weekly:
+		return date.Weekday() == dtstart.Weekday()
+	}
+	return true
+}
+
+// Pick the candidates by BYSETPOS.
+func (r *Rule) setPos(candidates []time.Time) []time.Time {
+	if len(r.BySetPos) == 0 {
+		return candidates
+	}
+
+	picked := []time.Time{}
+	for _, pos := range r.BySetPos {
+		i := pos - 1
+		if pos < 0 {
+			i = len(candidates) + pos
+		}
+		if i < 0 || i >= len(candidates) {
+			continue
+		}
+		picked = append(picked, candidates[i])
+	}
+	slices.SortFunc(picked, func(a, b time.Time) int {
+		return a.Compare(b)
+	})
+	return slices.CompactFunc(picked, func(a, b time.Time) bool {
+		return a.Equal(b)
+	})
+}
+
+func matchOrdinal(ordinals []int, n, total int) bool {
+	for _, o := range ordinals {
+		if o == n || o == n-total-1 {
+			return true
+		}
+	}
+	return false
+}
+
+func matchInt(values []int, n int) bool {
+	return len(values) == 0 || slices.Contains(values, n)
+}
+
+func or(values []int, fallback int) []int {
+	if len(values) == 0 {
+		return []int{fallback}
+	}
+	return values
+}
+
+func daysIn(date time.Time) int {
+	return time.Date(date.Year(), date.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// Represents the rule as the value of RRULE. UNTIL is expressed in UTC.
+func (r *Rule) String() string {
+	return r.format(ical.FormatUTC(r.Until))
+}
+
+// Represents the rule as the value of RRULE with UNTIL as a DATE, for an all-day event whose DTSTART is a DATE.
+func (r *Rule) DateString() string {
+	return r.format(r.Until.Format(ical.DateFormat))
+}
+
+func (r *Rule) format(until string) string {
+	parts := []string{}
+	for name, frequency := range frequencies {
+		if frequency == r.Frequency {
+			parts = append(parts, "FREQ="+name)
+		}
+	}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+until)
+	}
+
+	months := make([]int, len(r.ByMonth))
+	for i, m := range r.ByMonth {
+		months[i] = int(m)
+	}
+	parts = appendInts(parts, "BYMONTH", months)
+	parts = appendInts(parts, "BYYEARDAY", r.ByYearDay)
+	parts = appendInts(parts, "BYMONTHDAY", r.ByMonthDay)
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, wd := range r.ByDay {
+			days[i] = weekdayName(wd.Weekday)
+			if wd.N != 0 {
+				days[i] = strconv.Itoa(wd.N) + days[i]
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	parts = appendInts(parts, "BYHOUR", r.ByHour)
+	parts = appendInts(parts, "BYMINUTE", r.ByMinute)
+	parts = appendInts(parts, "BYSECOND", r.BySecond)
+	parts = appendInts(parts, "BYSETPOS", r.BySetPos)
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayName(r.WeekStart))
+	}
+	return strings.Join(parts, ";")
+}
+
+func appendInts(parts []string, name string, values []int) []string {
+	if len(values) == 0 {
+		return parts
+	}
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = strconv.Itoa(v)
+	}
+	return append(parts, name+"="+strings.Join(s, ","))
+}
+
+func weekdayName(weekday time.Weekday) string {
+	for name, wd := range weekdays {
+		if wd == weekday {
+			return name
+		}
+	}
+	return ""
+}
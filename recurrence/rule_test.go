@@ -0,0 +1,262 @@
+package recurrence_test
+
+import (
+	"testing"
+	"time"
+
+	"timeslots/recurrence"
+)
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "Weekly", value: "FREQ=WEEKLY;BYDAY=TU,TH", wantErr: false},
+		{name: "With prefix", value: "RRULE:FREQ=DAILY;COUNT=3", wantErr: false},
+		{name: "Monthly with ordinal", value: "FREQ=MONTHLY;BYDAY=-1FR;UNTIL=20241231T235959Z", wantErr: false},
+		{name: "Missing frequency", value: "BYDAY=TU", wantErr: true},
+		{name: "Unknown frequency", value: "FREQ=SECONDLY", wantErr: true},
+		{name: "Unsupported part", value: "FREQ=YEARLY;BYWEEKNO=20", wantErr: true},
+		{name: "Invalid weekday", value: "FREQ=WEEKLY;BYDAY=XX", wantErr: true},
+		{name: "Out of range", value: "FREQ=MONTHLY;BYMONTHDAY=32", wantErr: true},
+		{name: "Count with until", value: "FREQ=DAILY;COUNT=3;UNTIL=20241231", wantErr: true},
+		{name: "Zero interval", value: "FREQ=DAILY;INTERVAL=0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := recurrence.ParseRule(tt.value, time.UTC)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseRule() error = %v, wantErr = %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRuleOccurrences(t *testing.T) {
+	dtstart := time.Date(2024, 9, 3, 10, 0, 0, 0, time.UTC) // Tuesday
+
+	tests := []struct {
+		name string
+		rule string
+		from time.Time
+		to   time.Time
+		want []string
+	}{
+		{
+			name: "Daily with count",
+			rule: "FREQ=DAILY;COUNT=3",
+			from: dtstart,
+			to:   dtstart.AddDate(1, 0, 0),
+			want: []string{"2024-09-03T10:00", "2024-09-04T10:00", "2024-09-05T10:00"},
+		},
+		{
+			name: "Weekly by days with interval",
+			rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,FR",
+			from: dtstart,
+			to:   dtstart.AddDate(0, 0, 21),
+			want: []string{"2024-09-03T10:00", "2024-09-06T10:00", "2024-09-17T10:00", "2024-09-20T10:00"},
+		},
+		{
+			name: "Monthly last friday",
+			rule: "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3",
+			from: dtstart,
+			to:   dtstart.AddDate(1, 0, 0),
+			want: []string{"2024-09-03T10:00", "2024-09-27T10:00", "2024-10-25T10:00", "2024-11-29T10:00"},
+		},
+		{
+			name: "Monthly by negative month day",
+			rule: "FREQ=MONTHLY;BYMONTHDAY=-1;UNTIL=20241201T000000Z",
+			from: dtstart,
+			to:   dtstart.AddDate(1, 0, 0),
+			want: []string{"2024-09-03T10:00", "2024-09-30T10:00", "2024-10-31T10:00", "2024-11-30T10:00"},
+		},
+		{
+			name: "Monthly on the 31st skips short months",
+			rule: "FREQ=MONTHLY;BYMONTHDAY=31;COUNT=2",
+			from: dtstart,
+			to:   dtstart.AddDate(1, 0, 0),
+			want: []string{"2024-09-03T10:00", "2024-10-31T10:00", "2024-12-31T10:00"},
+		},
+		{
+			name: "Monthly last weekday by set position",
+			rule: "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1;COUNT=2",
+			from: dtstart,
+			to:   dtstart.AddDate(1, 0, 0),
+			want: []string{"2024-09-03T10:00", "2024-09-30T10:00", "2024-10-31T10:00"},
+		},
+		{
+			name: "Yearly by month",
+			rule: "FREQ=YEARLY;BYMONTH=9,10;COUNT=3",
+			from: dtstart,
+			to:   dtstart.AddDate(3, 0, 0),
+			want: []string{"2024-09-03T10:00", "2024-10-03T10:00", "2025-09-03T10:00"},
+		},
+		{
+			name: "Daily by hours",
+			rule: "FREQ=DAILY;BYHOUR=10,14;COUNT=3",
+			from: dtstart,
+			to:   dtstart.AddDate(1, 0, 0),
+			want: []string{"2024-09-03T10:00", "2024-09-03T14:00", "2024-09-04T10:00"},
+		},
+		{
+			name: "Hourly with interval",
+			rule: "FREQ=HOURLY;INTERVAL=3;COUNT=3",
+			from: dtstart,
+			to:   dtstart.AddDate(1, 0, 0),
+			want: []string{"2024-09-03T10:00", "2024-09-03T13:00", "2024-09-03T16:00"},
+		},
+		{
+			name: "Endless rule is limited by the period",
+			rule: "FREQ=WEEKLY",
+			from: dtstart.AddDate(10, 0, 0),
+			to:   dtstart.AddDate(10, 0, 14),
+			want: []string{"2034-09-05T10:00", "2034-09-12T10:00"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := recurrence.ParseRule(tt.rule, time.UTC)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := &recurrence.Recurrence{
+				Start:    dtstart,
+				Duration: time.Hour,
+				Rules:    []*recurrence.Rule{rule},
+			}
+			assertTimes(t, r.Between(tt.from, tt.to), tt.want)
+		})
+	}
+}
+
+func TestRuleSubdailyKeepsStart(t *testing.T) {
+	tests := []struct {
+		name    string
+		dtstart time.Time
+		rule    string
+		want    []string
+	}{
+		{
+			name:    "Hourly from half past",
+			dtstart: time.Date(2024, 9, 3, 10, 30, 0, 0, time.UTC),
+			rule:    "FREQ=HOURLY;COUNT=3",
+			want:    []string{"10:30:00", "11:30:00", "12:30:00"},
+		},
+		{
+			name:    "Hourly with seconds",
+			dtstart: time.Date(2024, 9, 3, 10, 15, 30, 0, time.UTC),
+			rule:    "FREQ=HOURLY;INTERVAL=2;COUNT=3",
+			want:    []string{"10:15:30", "12:15:30", "14:15:30"},
+		},
+		{
+			name:    "Minutely with seconds",
+			dtstart: time.Date(2024, 9, 3, 10, 15, 30, 0, time.UTC),
+			rule:    "FREQ=MINUTELY;INTERVAL=15;COUNT=3",
+			want:    []string{"10:15:30", "10:30:30", "10:45:30"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := recurrence.ParseRule(tt.rule, time.UTC)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := &recurrence.Recurrence{
+				Start:    tt.dtstart,
+				Duration: time.Minute,
+				Rules:    []*recurrence.Rule{rule},
+			}
+			got := r.Between(tt.dtstart, tt.dtstart.AddDate(0, 0, 1))
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d occurrences %v, want %v", len(got), got, tt.want)
+			}
+			for i, w := range tt.want {
+				if got[i].Format(time.TimeOnly) != w {
+					t.Errorf("occurrence[%d] = %v, want %v", i, got[i].Format(time.TimeOnly), w)
+				}
+			}
+		})
+	}
+}
+
+func TestRuleSubdailyFallBack(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	dtstart := time.Date(2024, 11, 3, 0, 30, 0, 0, newYork)
+
+	tests := []struct {
+		rule string
+		want []string
+	}{
+		{
+			rule: "FREQ=HOURLY;COUNT=4",
+			want: []string{"00:30:00-04:00", "01:30:00-04:00", "01:30:00-05:00", "02:30:00-05:00"},
+		},
+		{
+			rule: "FREQ=MINUTELY;INTERVAL=30;COUNT=5",
+			want: []string{"00:30:00-04:00", "01:00:00-04:00", "01:30:00-04:00", "01:00:00-05:00", "01:30:00-05:00"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			rule, err := recurrence.ParseRule(tt.rule, newYork)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := &recurrence.Recurrence{
+				Start:    dtstart,
+				Duration: time.Minute,
+				Rules:    []*recurrence.Rule{rule},
+			}
+			got := r.Between(dtstart, dtstart.AddDate(0, 0, 1))
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d occurrences %v, want %v", len(got), got, tt.want)
+			}
+			for i, w := range tt.want {
+				if got[i].Format("15:04:05Z07:00") != w {
+					t.Errorf("occurrence[%d] = %v, want %v", i, got[i].Format("15:04:05Z07:00"), w)
+				}
+			}
+		})
+	}
+}
+
+func assertTimes(t *testing.T, got []time.Time, want []string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d occurrences %v, want %d occurrences %v", len(got), got, len(want), want)
+	}
+	for i, w := range want {
+		if got[i].Format("2006-01-02T15:04") != w {
+			t.Errorf("occurrence[%d] = %v, want %v", i, got[i].Format("2006-01-02T15:04"), w)
+		}
+	}
+}
+
+func TestRuleString(t *testing.T) {
+	tests := []string{
+		"FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,FR",
+		"FREQ=MONTHLY;UNTIL=20241231T235959Z;BYDAY=-1FR",
+		"FREQ=YEARLY;COUNT=3;BYMONTH=9,10;BYMONTHDAY=1;BYHOUR=10;BYSETPOS=1;WKST=SU",
+	}
+
+	for _, value := range tests {
+		t.Run(value, func(t *testing.T) {
+			rule, err := recurrence.ParseRule(value, time.UTC)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := rule.String(); got != value {
+				t.Errorf("String() = %v, want %v", got, value)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package timeslots
+
+import (
+	"fmt"
+	"sync"
+)
+
+// This is returned when a Block cannot be booked because of the existing Blocks.
+type ConflictError struct {
+	Block     *Block
+	Conflicts []*Block
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s conflicts with %d block(s)", format(e.Block), len(e.Conflicts))
+}
+
+// This is a schedule of a resource which is safe for concurrent use. Booking is atomic, so double-booking is impossible.
+type Schedule struct {
+	mu    sync.RWMutex
+	index *Index
+}
+
+// Creates a new Schedule with the existing Blocks. They are not checked for conflicts.
+func NewSchedule(blocks ...*Block) *Schedule {
+	return &Schedule{
+		index: NewIndex(blocks...),
+	}
+}
+
+// Add the Block if it is still free. Otherwise it returns *ConflictError with the conflicting Blocks.
+// The Block conflicts with an existing Block when either one, expanded by its own buffer, overlaps the other.
+func (s *Schedule) Book(block *Block) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if conflicts := s.index.conflicting(block); len(conflicts) > 0 {
+		return &ConflictError{
+			Block:     block,
+			Conflicts: conflicts,
+		}
+	}
+	s.index.Insert(block)
+	return nil
+}
+
+// Remove the booked Block. It reports whether the Block was found.
+func (s *Schedule) Cancel(block *Block) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.index.Delete(block)
+}
+
+// It returns a list of available time slots within the Span.
+func (s *Schedule) Find(span *Span, opts ...Option[*Slot]) []*Slot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.index.Find(span, opts...)
+}
+
+// Whether the Period is fully free. It uses the same rule as Book, so the buffers are considered.
+func (s *Schedule) IsFree(p Period) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.index.IsFree(p)
+}
+
+// The Blocks conflicting with the Period, in order of the start time. It uses the same rule as Book, so the buffers are considered.
+func (s *Schedule) Conflicts(p Period) []*Block {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.index.Overlapping(p)
+}
+
+// All the booked Blocks in order of the start time.
+func (s *Schedule) Blocks() []*Block {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.index.Blocks()
+}
+
+// The number of the booked Blocks.
+func (s *Schedule) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.index.Len()
+}
@@ -0,0 +1,114 @@
+package timeslots_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+	"timeslots"
+	"timeslots/internal/slice"
+)
+
+func TestScheduleBook(t *testing.T) {
+	h := NewTestingHelper(now)
+	existing := h.Block(2, 4)
+	buffered := h.Block(6, 7).WithBuffer(2*time.Hour, 0)
+	schedule := timeslots.NewSchedule(existing)
+
+	tests := []struct {
+		name          string
+		block         *timeslots.Block
+		wantConflicts []*timeslots.Block
+	}{
+		{name: "Free", block: h.Block(0, 1), wantConflicts: nil},
+		{name: "Touching", block: h.Block(1, 2), wantConflicts: nil},
+		{name: "Overlapping", block: h.Block(3, 5), wantConflicts: []*timeslots.Block{existing}},
+		{name: "Buffer overlaps", block: h.Block(5, 6).WithBuffer(2*time.Hour, 0), wantConflicts: []*timeslots.Block{existing}},
+		{name: "Free with buffer", block: buffered, wantConflicts: nil},
+		{name: "Overlaps the buffer of a booked block", block: h.Block(5, 6), wantConflicts: []*timeslots.Block{buffered}},
+		{name: "Touching the end of a booked block", block: h.Block(7, 8), wantConflicts: nil},
+		{name: "Overlapping many", block: h.Block(0, 8), wantConflicts: []*timeslots.Block{h.Block(0, 1), h.Block(1, 2), existing, buffered, h.Block(7, 8)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := schedule.Book(tt.block)
+			if tt.wantConflicts == nil {
+				if err != nil {
+					t.Errorf("Book() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var conflict *timeslots.ConflictError
+			if !errors.As(err, &conflict) {
+				t.Fatalf("Book() error = %v, want ConflictError", err)
+			}
+			if conflict.Block != tt.block || timeslots.ToString(conflict.Conflicts) != timeslots.ToString(tt.wantConflicts) {
+				t.Errorf("Conflicts = %v, want %v", timeslots.ToString(conflict.Conflicts), timeslots.ToString(tt.wantConflicts))
+			}
+		})
+	}
+
+	if schedule.Len() != 5 {
+		t.Errorf("Len() = %d, want 5", schedule.Len())
+	}
+	if got := schedule.Find(h.Span(0, 10)); !slice.Equal(got, []*timeslots.Slot{h.Slot(8, 10)}) {
+		t.Errorf("Find() = %v", slice.String(got))
+	}
+	// IsFree and Conflicts agree with Book about the buffers.
+	if schedule.IsFree(h.Slot(5, 6)) || timeslots.ToString(schedule.Conflicts(h.Slot(5, 6))) != timeslots.ToString([]*timeslots.Block{buffered}) {
+		t.Errorf("IsFree() or Conflicts() ignores the buffer of a booked block")
+	}
+	if !schedule.Cancel(existing) || !schedule.IsFree(h.Slot(2, 4)) || len(schedule.Conflicts(h.Slot(0, 3))) != 2 {
+		t.Errorf("Cancel() failed")
+	}
+}
+
+func TestScheduleConcurrentBooking(t *testing.T) {
+	h := NewTestingHelper(now)
+	schedule := timeslots.NewSchedule()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	booked := 0
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := schedule.Book(h.Block(i%10, i%10+2)); err == nil {
+				mu.Lock()
+				booked++
+				mu.Unlock()
+			}
+			schedule.Find(h.Span(0, 12))
+		}(i)
+	}
+	wg.Wait()
+
+	blocks := schedule.Blocks()
+	if booked != len(blocks) {
+		t.Errorf("booked %d, but the schedule has %d blocks", booked, len(blocks))
+	}
+	for i := 1; i < len(blocks); i++ {
+		if blocks[i].Overlaps(blocks[i-1]) {
+			t.Errorf("double-booked: %v and %v", blocks[i-1], blocks[i])
+		}
+	}
+}
+
+func TestScheduleIsFreeAgreesWithBook(t *testing.T) {
+	h := NewTestingHelper(now)
+	schedule := timeslots.NewSchedule(h.Block(10, 11).WithBuffer(0, time.Hour))
+	proposed := timeslots.NewBlockWithoutValidating(now.Add(11*time.Hour), now.Add(11*time.Hour+30*time.Minute))
+
+	if schedule.IsFree(proposed) {
+		t.Errorf("IsFree(%v) = true, want false", proposed)
+	}
+	if err := schedule.Book(proposed); err == nil {
+		t.Errorf("Book(%v) error = nil, want ConflictError", proposed)
+	}
+	if !schedule.IsFree(h.Slot(12, 13)) || schedule.Book(h.Block(12, 13)) != nil {
+		t.Errorf("IsFree() and Book() disagree after the buffer")
+	}
+}
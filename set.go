@@ -0,0 +1,154 @@
+package timeslots
+
+import (
+	"sort"
+)
+
+// This is a set of periods. It is always normalized, so the periods are sorted, non-empty, and neither overlap nor touch each other.
+// The Set is immutable, and every operation returns a new Set.
+type Set struct {
+	slots []*Slot
+}
+
+// Creates a new Set from any periods. Overlapping and adjacent periods are merged.
+func NewSet[T Period](periods []T) *Set {
+	slots := make([]*Slot, 0, len(periods))
+	for _, p := range periods {
+		if p.Start().Before(p.End()) {
+			slots = append(slots, newSlot(p.Start(), p.End()))
+		}
+	}
+	sort.Slice(slots, func(i, j int) bool {
+		return slots[i].start.Before(slots[j].start)
+	})
+
+	j := 0
+	for _, slot := range slots {
+		if j > 0 && beforeEq(slot.start, slots[j-1].end) {
+			if slot.end.After(slots[j-1].end) {
+				slots[j-1] = newSlot(slots[j-1].start, slot.end)
+			}
+			continue
+		}
+		slots[j] = slot
+		j++
+	}
+	return &Set{slots: slots[:j]}
+}
+
+// Sort and merge the periods into Slots. It is the same as NewSet(periods).Slots().
+func Normalize[T Period](periods []T) []*Slot {
+	return NewSet(periods).Slots()
+}
+
+// The periods of the Set as Slots.
+func (s *Set) Slots() []*Slot {
+	slots := make([]*Slot, len(s.slots))
+	for i, slot := range s.slots {
+		slots[i] = newSlot(slot.start, slot.end)
+	}
+	return slots
+}
+
+// The periods of the Set as Blocks.
+func (s *Set) Blocks() []*Block {
+	blocks := make([]*Block, len(s.slots))
+	for i, slot := range s.slots {
+		blocks[i] = NewBlockWithoutValidating(slot.start, slot.end)
+	}
+	return blocks
+}
+
+// The number of periods in the Set.
+func (s *Set) Len() int {
+	return len(s.slots)
+}
+
+// Whether the Set has no period.
+func (s *Set) IsEmpty() bool {
+	return len(s.slots) == 0
+}
+
+// Whether two Sets represent the same periods.
+func (s *Set) Equal(other *Set) bool {
+	if len(s.slots) != len(other.slots) {
+		return false
+	}
+	for i, slot := range s.slots {
+		if !slot.Equal(other.slots[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Represents the periods as strings.
+func (s *Set) String() string {
+	return ToString(s.slots)
+}
+
+// The periods in either Set.
+func (s *Set) Union(other *Set) *Set {
+	slots := make([]*Slot, 0, len(s.slots)+len(other.slots))
+	slots = append(slots, s.slots...)
+	slots = append(slots, other.slots...)
+	return NewSet(slots)
+}
+
+// The periods in both Sets.
+func (s *Set) Intersect(other *Set) *Set {
+	slots := []*Slot{}
+	i, j := 0, 0
+	for i < len(s.slots) && j < len(other.slots) {
+		a, b := s.slots[i], other.slots[j]
+		start, end := a.start, a.end
+		if b.start.After(start) {
+			start = b.start
+		}
+		if b.end.Before(end) {
+			end = b.end
+		}
+		if start.Before(end) {
+			slots = append(slots, newSlot(start, end))
+		}
+		if a.end.Before(b.end) {
+			i++
+			continue
+		}
+		j++
+	}
+	return &Set{slots: slots}
+}
+
+// The periods in the Set but not in the other Set.
+func (s *Set) Subtract(other *Set) *Set {
+	slots := []*Slot{}
+	j := 0
+	for _, slot := range s.slots {
+		start := slot.start
+		for j < len(other.slots) && beforeEq(other.slots[j].end, start) {
+			j++
+		}
+		for k := j; k < len(other.slots) && other.slots[k].start.Before(slot.end); k++ {
+			cut := other.slots[k]
+			if start.Before(cut.start) {
+				slots = append(slots, newSlot(start, cut.start))
+			}
+			if cut.end.After(start) {
+				start = cut.end
+			}
+		}
+		if start.Before(slot.end) {
+			slots = append(slots, newSlot(start, slot.end))
+		}
+	}
+	return &Set{slots: slots}
+}
+
+// The periods within the Span not in the Set.
+func (s *Set) Complement(span *Span) *Set {
+	if span == nil {
+		return &Set{slots: []*Slot{}}
+	}
+	return NewSet([]*Span{span}).Subtract(s)
+}
@@ -0,0 +1,91 @@
+package timeslots_test
+
+import (
+	"testing"
+	"timeslots"
+)
+
+func TestSet(t *testing.T) {
+	h := NewTestingHelper(now)
+	set := func(periods ...timeslots.Period) *timeslots.Set {
+		return timeslots.NewSet(periods)
+	}
+
+	tests := []struct {
+		name string
+		got  *timeslots.Set
+		want *timeslots.Set
+	}{
+		{
+			name: "Normalize overlapping, adjacent, unsorted and empty periods",
+			got:  set(h.Block(5, 6), h.Block(0, 2), h.Slot(1, 3), h.Span(3, 4), h.Block(7, 7)),
+			want: set(h.Slot(0, 4), h.Slot(5, 6)),
+		},
+		{
+			name: "Union",
+			got:  set(h.Slot(0, 2), h.Slot(6, 7)).Union(set(h.Block(1, 3), h.Block(8, 9))),
+			want: set(h.Slot(0, 3), h.Slot(6, 7), h.Slot(8, 9)),
+		},
+		{
+			name: "Intersect",
+			got:  set(h.Slot(0, 4), h.Slot(6, 9)).Intersect(set(h.Block(1, 2), h.Block(3, 7), h.Block(8, 10))),
+			want: set(h.Slot(1, 2), h.Slot(3, 4), h.Slot(6, 7), h.Slot(8, 9)),
+		},
+		{
+			name: "Intersect touching periods",
+			got:  set(h.Slot(0, 4)).Intersect(set(h.Block(4, 5))),
+			want: set(),
+		},
+		{
+			name: "Subtract",
+			got:  set(h.Slot(0, 8), h.Slot(10, 12)).Subtract(set(h.Block(-1, 1), h.Block(2, 3), h.Block(7, 11))),
+			want: set(h.Slot(1, 2), h.Slot(3, 7), h.Slot(11, 12)),
+		},
+		{
+			name: "Subtract everything",
+			got:  set(h.Slot(0, 8)).Subtract(set(h.Block(-1, 9))),
+			want: set(),
+		},
+		{
+			name: "Complement",
+			got:  set(h.Block(-1, 1), h.Block(2, 3)).Complement(h.Span(0, 8)),
+			want: set(h.Slot(1, 2), h.Slot(3, 8)),
+		},
+		{
+			name: "Complement with nil span",
+			got:  set(h.Block(-1, 1)).Complement(nil),
+			want: set(),
+		},
+		{
+			name: "Compose rules",
+			got: set(h.Span(0, 10)).
+				Subtract(set(h.Block(4, 5))).
+				Subtract(set(h.Block(1, 2))).
+				Intersect(set(h.Slot(0, 6))),
+			want: set(h.Slot(0, 1), h.Slot(2, 4), h.Slot(5, 6)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.got.Equal(tt.want) {
+				t.Errorf("got: %v, want: %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetComplementEqualsFind(t *testing.T) {
+	h := NewTestingHelper(now)
+	blocks := h.HugeBlocks(1, 99)
+	span := h.Span(0, 99)
+
+	got := timeslots.NewSet(blocks).Complement(span)
+	want := timeslots.NewSet(timeslots.Find(blocks, span))
+	if !got.Equal(want) || got.Len() != 50 {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+	if got.IsEmpty() || len(got.Blocks()) != got.Len() || len(timeslots.Normalize(blocks)) != 49 {
+		t.Errorf("unexpected size")
+	}
+}
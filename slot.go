@@ -1,7 +1,6 @@
 package timeslots
 
 import (
-	"fmt"
 	"time"
 )
 
@@ -21,8 +20,8 @@ func newSlot(start, end time.Time) *Slot {
 
 // Creates a new Slot.
 func NewSlot(start, end time.Time) (*Slot, error) {
-	if start.After(end) {
-		return nil, fmt.Errorf("invalid time arguments")
+	if err := validate(start, end); err != nil {
+		return nil, err
 	}
 	return newSlot(start, end), nil
 }
@@ -41,6 +40,18 @@ func (s *Slot) End() time.Time {
 	return s.end
 }
 
+// Copy the Slot with the start time and end time converted into the location.
+func (s *Slot) In(loc *time.Location) *Slot {
+	return newSlot(s.start.In(loc), s.end.In(loc))
+}
+
+// Convert the start time and end time into the location in place. A nil location keeps them.
+func (s *Slot) localize(loc *time.Location) {
+	if loc != nil {
+		s.start, s.end = s.start.In(loc), s.end.In(loc)
+	}
+}
+
 // Represents the start time and end time as strings.
 func (s *Slot) String() string {
 	return format(s)
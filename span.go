@@ -14,12 +14,21 @@ type Span struct {
 
 // Specify the period you want to search.
 func NewSpan(start, end time.Time) (*Span, error) {
-	if start.After(end) {
-		return nil, fmt.Errorf("invalid time arguments")
+	if err := validate(start, end); err != nil {
+		return nil, err
 	}
 	return newSpan(start, end), nil
 }
 
+// Specify the period you want to search in the location. (e.g. the time zone of the user loaded by time.LoadLocation)
+// The start and end are converted into the location, and the Slots found in the Span are expressed in it.
+func NewSpanInLocation(start, end time.Time, loc *time.Location) (*Span, error) {
+	if loc == nil {
+		return nil, fmt.Errorf("nil location")
+	}
+	return NewSpan(start.In(loc), end.In(loc))
+}
+
 func newSpan(start, end time.Time) *Span {
 	return &Span{
 		start: start,
@@ -37,6 +46,16 @@ func (s *Span) End() time.Time {
 	return s.end
 }
 
+// Location of the start time.
+func (s *Span) Location() *time.Location {
+	return s.start.Location()
+}
+
+// Copy the Span with the start time and end time converted into the location.
+func (s *Span) In(loc *time.Location) *Span {
+	return newSpan(s.start.In(loc), s.end.In(loc))
+}
+
 // Represents the start time and end time as strings.
 func (s *Span) String() string {
 	return format(s)
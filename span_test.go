@@ -145,3 +145,26 @@ func TestSpanString(t *testing.T) {
 		t.Errorf("Slot.String() = %s; want %s", got, want)
 	}
 }
+
+func TestNewSpanInLocation(t *testing.T) {
+	tokyo := time.FixedZone("Asia/Tokyo", 9*60*60)
+	start := time.Date(2024, 9, 26, 0, 0, 0, 0, time.UTC)
+
+	span, err := timeslots.NewSpanInLocation(start, start.Add(time.Hour), tokyo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if span.Location() != tokyo {
+		t.Errorf("Location() = %v, want %v", span.Location(), tokyo)
+	}
+	if got := span.String(); got != "2024-09-26 09:00:00, 2024-09-26 10:00:00" {
+		t.Errorf("String() = %v", got)
+	}
+	if got := span.In(time.UTC).String(); got != "2024-09-26 00:00:00, 2024-09-26 01:00:00" {
+		t.Errorf("In(UTC).String() = %v", got)
+	}
+
+	if _, err := timeslots.NewSpanInLocation(start, start.Add(time.Hour), nil); err == nil {
+		t.Error("NewSpanInLocation() with nil location, want error")
+	}
+}
@@ -0,0 +1,98 @@
+package timeslots
+
+import (
+	"time"
+)
+
+// Options for Split
+type SplitOptions struct {
+	Step   time.Duration
+	Grid   time.Duration
+	Anchor time.Time
+}
+
+// Whether the Grid is set to SplitOptions
+func (o *SplitOptions) IsSetGrid() bool {
+	return o.Grid > 0
+}
+
+// Option Func for Split
+type SplitOption func(*SplitOptions)
+
+// Run with the interval between the start times of consecutive candidates. By default, it is the same as the length.
+func WithStep(step time.Duration) SplitOption {
+	return func(opts *SplitOptions) {
+		opts.Step = step
+	}
+}
+
+// Run with the grid on which the candidates start. (e.g. every 15 minutes from the anchor)
+// If the anchor is zero, midnight of the day in the location of each Slot is used.
+func WithGrid(grid time.Duration, anchor time.Time) SplitOption {
+	return func(opts *SplitOptions) {
+		opts.Grid = grid
+		opts.Anchor = anchor
+	}
+}
+
+// Split available time slots (Slot) into bookable candidates of the fixed length. The candidates may overlap each other when the step is shorter than the length.
+// The candidates are expressed in the location of each Slot.
+func Split(slots []*Slot, length time.Duration, opts ...SplitOption) []*Slot {
+	options := SplitOptions{
+		Step: length,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if length <= 0 || options.Step <= 0 {
+		return []*Slot{}
+	}
+
+	candidates := []*Slot{}
+	for _, slot := range slots {
+		anchor := options.Anchor
+		if anchor.IsZero() {
+			anchor = midnight(slot.start)
+		}
+
+		start := slot.start
+		if options.IsSetGrid() {
+			start = alignUp(start, options.Grid, anchor)
+		}
+		for end := start.Add(length); beforeEq(end, slot.end); end = start.Add(length) {
+			candidates = append(candidates, newSlot(start, end))
+			start = start.Add(options.Step)
+			if options.IsSetGrid() {
+				start = alignUp(start, options.Grid, anchor)
+			}
+		}
+	}
+	return candidates
+}
+
+// Round the time up to the grid from the anchor.
+func alignUp(t time.Time, grid time.Duration, anchor time.Time) time.Time {
+	offset := t.Sub(anchor) % grid
+	if offset < 0 {
+		offset += grid
+	}
+	if offset == 0 {
+		return t
+	}
+	return t.Add(grid - offset)
+}
+
+// Round the time down to the grid from the anchor.
+func alignDown(t time.Time, grid time.Duration, anchor time.Time) time.Time {
+	offset := t.Sub(anchor) % grid
+	if offset < 0 {
+		offset += grid
+	}
+	return t.Add(-offset)
+}
+
+func midnight(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
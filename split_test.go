@@ -0,0 +1,86 @@
+package timeslots_test
+
+import (
+	"testing"
+	"time"
+	"timeslots"
+	"timeslots/internal/slice"
+)
+
+func TestSplit(t *testing.T) {
+	base := time.Date(2024, 9, 26, 9, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time {
+		return base.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+	slot := func(sh, sm, eh, em int) *timeslots.Slot {
+		s, _ := timeslots.NewSlot(at(sh, sm), at(eh, em))
+		return s
+	}
+
+	tests := []struct {
+		name   string
+		slots  []*timeslots.Slot
+		length time.Duration
+		opts   []timeslots.SplitOption
+		want   []*timeslots.Slot
+	}{
+		{
+			name:   "No slots",
+			slots:  []*timeslots.Slot{},
+			length: 30 * time.Minute,
+			want:   []*timeslots.Slot{},
+		},
+		{
+			name:   "Zero length",
+			slots:  []*timeslots.Slot{slot(0, 0, 1, 0)},
+			length: 0,
+			want:   []*timeslots.Slot{},
+		},
+		{
+			name:   "Back to back",
+			slots:  []*timeslots.Slot{slot(0, 0, 1, 40), slot(2, 0, 2, 20)},
+			length: 30 * time.Minute,
+			want:   []*timeslots.Slot{slot(0, 0, 0, 30), slot(0, 30, 1, 0), slot(1, 0, 1, 30)},
+		},
+		{
+			name:   "With step",
+			slots:  []*timeslots.Slot{slot(0, 0, 1, 0)},
+			length: 30 * time.Minute,
+			opts:   []timeslots.SplitOption{timeslots.WithStep(15 * time.Minute)},
+			want:   []*timeslots.Slot{slot(0, 0, 0, 30), slot(0, 15, 0, 45), slot(0, 30, 1, 0)},
+		},
+		{
+			name:   "With grid",
+			slots:  []*timeslots.Slot{slot(0, 7, 1, 20)},
+			length: 30 * time.Minute,
+			opts:   []timeslots.SplitOption{timeslots.WithGrid(15*time.Minute, time.Time{})},
+			want:   []*timeslots.Slot{slot(0, 15, 0, 45), slot(0, 45, 1, 15)},
+		},
+		{
+			name:   "With step and grid",
+			slots:  []*timeslots.Slot{slot(0, 7, 1, 20)},
+			length: 30 * time.Minute,
+			opts: []timeslots.SplitOption{
+				timeslots.WithStep(10 * time.Minute),
+				timeslots.WithGrid(15*time.Minute, time.Time{}),
+			},
+			want: []*timeslots.Slot{slot(0, 15, 0, 45), slot(0, 30, 1, 0), slot(0, 45, 1, 15)},
+		},
+		{
+			name:   "With grid and anchor",
+			slots:  []*timeslots.Slot{slot(0, 0, 1, 0)},
+			length: 20 * time.Minute,
+			opts:   []timeslots.SplitOption{timeslots.WithGrid(20*time.Minute, at(0, 5))},
+			want:   []*timeslots.Slot{slot(0, 5, 0, 25), slot(0, 25, 0, 45)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := timeslots.Split(tt.slots, tt.length, tt.opts...)
+			if !slice.Equal(got, tt.want) {
+				t.Errorf("got: %v, want: %v", slice.String(got), slice.String(tt.want))
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package timeslots
+
+import (
+	"iter"
+)
+
+// It yields available time slots lazily, consuming the blocks sorted by the start time one by one.
+// Use this for a long Span or a huge number of blocks, or to stop early. (e.g. the first 5 free slots)
+// The blocks must be sorted by the start time, including the buffers. It stops at the first block out of order, so use FindSeq2 to detect it.
+func FindSeq(blocks iter.Seq[*Block], span *Span, opts ...Option[*Slot]) iter.Seq[*Slot] {
+	return func(yield func(*Slot) bool) {
+		for slot, err := range FindSeq2(blocks, span, opts...) {
+			if err != nil || !yield(slot) {
+				return
+			}
+		}
+	}
+}
+
+// It is the same as FindSeq, but yields ErrUnsortedBlocks as the last element when a block starts before the previous one.
+func FindSeq2(blocks iter.Seq[*Block], span *Span, opts ...Option[*Slot]) iter.Seq2[*Slot, error] {
+	options := newOptions(opts)
+
+	padded := func(yield func(*Block) bool) {
+		for block := range blocks {
+			if !yield(block.padded(options.Buffer)) {
+				return
+			}
+		}
+	}
+
+	return func(yield func(*Slot, error) bool) {
+		span := options.clip(span)
+		stopped := false
+		err := scan(padded, span, options.location(span), func(slot *Slot) bool {
+			return options.constrain(slot, func(slot *Slot) bool {
+				if options.IsSetFilter() && options.FilterFunc(slot) {
+					return true
+				}
+				stopped = !yield(slot, nil)
+				return !stopped
+			})
+		})
+		if err != nil && !stopped {
+			yield(nil, err)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package timeslots_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+	"timeslots"
+	"timeslots/internal/slice"
+)
+
+func TestFindSeq(t *testing.T) {
+	h := NewTestingHelper(now)
+	tests := testCases(h)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sorted := slice.Clone(tt.blocks)
+			slices.SortFunc(sorted, func(a, b *timeslots.Block) int {
+				return a.Start().Compare(b.Start())
+			})
+
+			got := slices.Collect(timeslots.FindSeq(slices.Values(sorted), tt.search, timeslots.WithFilter(tt.filter)))
+			if !slice.Equal(got, tt.want) {
+				t.Errorf("got: %v, want: %v", slice.String(got), slice.String(tt.want))
+			}
+		})
+	}
+}
+
+func TestFindSeqStopsEarly(t *testing.T) {
+	h := NewTestingHelper(now)
+
+	consumed := 0
+	blocks := func(yield func(*timeslots.Block) bool) {
+		for i := 1; ; i += 2 {
+			consumed++
+			if !yield(h.Block(i, i+1)) {
+				return
+			}
+		}
+	}
+
+	got := []*timeslots.Slot{}
+	for slot := range timeslots.FindSeq(blocks, h.Span(0, 1_000_000)) {
+		got = append(got, slot)
+		if len(got) == 5 {
+			break
+		}
+	}
+
+	want := []*timeslots.Slot{h.Slot(0, 1), h.Slot(2, 3), h.Slot(4, 5), h.Slot(6, 7), h.Slot(8, 9)}
+	if !slice.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", slice.String(got), slice.String(want))
+	}
+	if consumed != 5 {
+		t.Errorf("consumed %d blocks, want 5", consumed)
+	}
+}
+
+func TestFindSeqWithBuffer(t *testing.T) {
+	h := NewTestingHelper(now)
+	blocks := []*timeslots.Block{h.Block(2, 3), h.Block(5, 6).WithBuffer(0, 0)}
+
+	got := slices.Collect(timeslots.FindSeq(slices.Values(blocks), h.Span(0, 8), timeslots.WithBuffer[*timeslots.Slot](time.Hour, time.Hour)))
+	want := []*timeslots.Slot{h.Slot(0, 1), h.Slot(4, 5), h.Slot(6, 8)}
+	if !slice.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", slice.String(got), slice.String(want))
+	}
+}
+
+func TestFindSeq2Unsorted(t *testing.T) {
+	h := NewTestingHelper(now)
+	blocks := []*timeslots.Block{h.Block(1, 2), h.Block(5, 6), h.Block(3, 4)}
+
+	got := []*timeslots.Slot{}
+	var err error
+	for slot, e := range timeslots.FindSeq2(slices.Values(blocks), h.Span(0, 8)) {
+		if e != nil {
+			err = e
+			break
+		}
+		got = append(got, slot)
+	}
+
+	if !errors.Is(err, timeslots.ErrUnsortedBlocks) {
+		t.Errorf("error = %v, want %v", err, timeslots.ErrUnsortedBlocks)
+	}
+	want := []*timeslots.Slot{h.Slot(0, 1), h.Slot(2, 5)}
+	if !slice.Equal(got, want) {
+		t.Errorf("got: %v, want: %v", slice.String(got), slice.String(want))
+	}
+
+	if n := len(slices.Collect(timeslots.FindSeq(slices.Values(blocks), h.Span(0, 8)))); n != 2 {
+		t.Errorf("FindSeq yielded %d slots, want 2", n)
+	}
+}
+
+func BenchmarkFindSeq(b *testing.B) {
+	h := NewTestingHelper(now)
+	blocks := h.HugeBlocks(1, 799)
+	span := h.Span(0, 799)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for range timeslots.FindSeq(slices.Values(blocks), span) {
+		}
+	}
+}